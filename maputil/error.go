@@ -0,0 +1,25 @@
+package maputil
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 预定义的哨兵错误，可使用 errors.Is 进行判断。
+var (
+	// ErrEmptyPath 表示传入的键路径为空。
+	ErrEmptyPath = errors.New("bizutil.maputil: empty path")
+
+	// ErrPathNotMap 表示键路径的中间节点已存在但不是 map[string]any，无法继续向下写入。
+	ErrPathNotMap = errors.New("bizutil.maputil: path segment is not a map")
+
+	// ErrDuplicateKey 表示要求键唯一的转换中出现了重复的键。
+	ErrDuplicateKey = errors.New("bizutil.maputil: duplicate key")
+)
+
+// NewErrDuplicateKey 创建一个包含重复键及冲突元素下标的错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrDuplicateKey) 进行判断。
+func NewErrDuplicateKey(key any, firstIndex, secondIndex int) error {
+	return fmt.Errorf("key %v produced by elements at index %d and %d: %w", key, firstIndex, secondIndex, ErrDuplicateKey)
+}
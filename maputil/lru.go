@@ -0,0 +1,111 @@
+package maputil
+
+import "container/list"
+
+// LRU 是基于 map 和双向链表实现的固定容量最近最少使用缓存。
+//
+// 当 Put 使条目数超过容量时，最久未被访问的条目会被淘汰，并调用 OnEvict（若已设置）。
+// LRU 不是并发安全的，多个 goroutine 共享时需要调用方自行加锁。
+//
+// 类型参数:
+//   - K: 键类型
+//   - V: 值类型
+type LRU[K comparable, V any] struct {
+	// OnEvict 在条目因超出容量被淘汰时调用（可以为 nil）。
+	// 通过 Remove 主动删除的条目不会触发此回调。
+	OnEvict func(key K, value V)
+
+	capacity int                 // capacity 是最大条目数
+	ll       *list.List          // ll 按访问时间排列条目，队首为最近访问
+	items    map[K]*list.Element // items 是键到链表节点的索引
+}
+
+// lruEntry 是 LRU 链表节点中保存的条目。
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU 创建一个指定容量的 LRU 缓存。
+//
+// capacity 必须大于 0，否则触发 panic。
+//
+// 示例:
+//
+//	cache := NewLRU[string, int](2)
+//	cache.Put("a", 1)
+//	cache.Put("b", 2)
+//	cache.Get("a")    // "a" 变为最近访问
+//	cache.Put("c", 3) // 淘汰 "b"
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity <= 0 {
+		panic("maputil: LRU capacity must be greater than 0")
+	}
+	return &LRU[K, V]{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[K]*list.Element, capacity),
+	}
+}
+
+// Get 获取指定键的值，命中时该条目会被标记为最近访问。
+//
+// 返回值:
+//   - 第一个返回值为键对应的值，若键不存在则返回零值
+//   - 第二个返回值表示键是否存在
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.ll.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+// Put 写入键值对，并将该条目标记为最近访问。
+//
+// 若键已存在则更新其值；若写入后超出容量，淘汰最久未被访问的条目。
+func (c *LRU[K, V]) Put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.ll.Len() > c.capacity {
+		c.evictOldest()
+	}
+}
+
+// Remove 删除指定键，键不存在时不做任何操作。
+//
+// 返回值表示键是否存在。
+func (c *LRU[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.ll.Remove(e)
+	delete(c.items, key)
+	return true
+}
+
+// Len 返回缓存中的条目数量。
+func (c *LRU[K, V]) Len() int {
+	return c.ll.Len()
+}
+
+// evictOldest 淘汰最久未被访问的条目并触发 OnEvict。
+func (c *LRU[K, V]) evictOldest() {
+	e := c.ll.Back()
+	if e == nil {
+		return
+	}
+	c.ll.Remove(e)
+	entry := e.Value.(*lruEntry[K, V])
+	delete(c.items, entry.key)
+	if c.OnEvict != nil {
+		c.OnEvict(entry.key, entry.value)
+	}
+}
@@ -0,0 +1,126 @@
+package maputil
+
+import "testing"
+
+// ============== LRU 测试 ==============
+
+func TestLRU_GetPut(t *testing.T) {
+	c := NewLRU[string, int](2)
+	if _, ok := c.Get("a"); ok {
+		t.Error("expected miss on empty cache")
+	}
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, ok)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected length 2, got %d", c.Len())
+	}
+
+	c.Put("a", 10)
+	if v, _ := c.Get("a"); v != 10 {
+		t.Errorf("expected updated value 10, got %d", v)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected length 2 after update, got %d", c.Len())
+	}
+}
+
+func TestLRU_EvictionOrder(t *testing.T) {
+	c := NewLRU[string, int](3)
+	var evicted []string
+	c.OnEvict = func(k string, _ int) { evicted = append(evicted, k) }
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Put("d", 4) // 淘汰 a
+	c.Put("e", 5) // 淘汰 b
+
+	if len(evicted) != 2 || evicted[0] != "a" || evicted[1] != "b" {
+		t.Errorf("expected evicted [a b], got %v", evicted)
+	}
+	if c.Len() != 3 {
+		t.Errorf("expected length 3, got %d", c.Len())
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Error("expected 'a' to be evicted")
+	}
+}
+
+func TestLRU_GetPromotesRecency(t *testing.T) {
+	c := NewLRU[string, int](2)
+	var evicted []string
+	c.OnEvict = func(k string, _ int) { evicted = append(evicted, k) }
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")    // a 变为最近访问
+	c.Put("c", 3) // 应淘汰 b
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("expected evicted [b], got %v", evicted)
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected 'a' to survive eviction")
+	}
+}
+
+func TestLRU_PutPromotesRecency(t *testing.T) {
+	c := NewLRU[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("a", 10) // 更新 a 同样提升其访问顺序
+	c.Put("c", 3)  // 应淘汰 b
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected 'b' to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 10 {
+		t.Errorf("expected (10, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestLRU_EvictCallbackValue(t *testing.T) {
+	c := NewLRU[string, int](1)
+	var gotKey string
+	var gotValue int
+	c.OnEvict = func(k string, v int) { gotKey, gotValue = k, v }
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if gotKey != "a" || gotValue != 1 {
+		t.Errorf("expected OnEvict(a, 1), got OnEvict(%s, %d)", gotKey, gotValue)
+	}
+}
+
+func TestLRU_Remove(t *testing.T) {
+	c := NewLRU[string, int](2)
+	evictCalled := false
+	c.OnEvict = func(string, int) { evictCalled = true }
+
+	c.Put("a", 1)
+	if !c.Remove("a") {
+		t.Error("expected Remove to return true for existing key")
+	}
+	if c.Remove("a") {
+		t.Error("expected Remove to return false for missing key")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected length 0, got %d", c.Len())
+	}
+	if evictCalled {
+		t.Error("OnEvict should not be called on Remove")
+	}
+}
+
+func TestNewLRU_InvalidCapacity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewLRU should panic for non-positive capacity")
+		}
+	}()
+	NewLRU[string, int](0)
+}
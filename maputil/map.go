@@ -1,6 +1,12 @@
 // Package maputil 提供了一组泛型 map 操作工具函数。
 package maputil
 
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
 // MapGet 从 map 中安全地获取值，并支持可选的值转换。
 //
 // 参数:
@@ -53,3 +59,1408 @@ func MapBy[T any, K comparable, V any](list []T, key func(T) K, value func(T) V)
 	}
 	return m
 }
+
+// GetOr 从 map 中获取值，若 key 不存在则返回指定的默认值。
+//
+// 参数:
+//   - m: 源 map，可以为 nil
+//   - key: 要查找的键
+//   - def: key 不存在时返回的默认值
+//
+// 返回值:
+//   - key 存在时返回对应的值，否则返回 def
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1}
+//	v := GetOr(m, "b", 10)
+//	// v = 10
+func GetOr[K comparable, V any](m map[K]V, key K, def V) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return def
+}
+
+// GetOrElse 从 map 中获取值，若 key 不存在则调用 def 计算默认值。
+//
+// 与 GetOr 不同，默认值是惰性计算的：仅在 key 不存在时才会调用 def，
+// 适用于默认值构造成本较高的场景。
+//
+// 参数:
+//   - m: 源 map，可以为 nil
+//   - key: 要查找的键
+//   - def: key 不存在时用于计算默认值的函数
+//
+// 返回值:
+//   - key 存在时返回对应的值，否则返回 def() 的结果
+//
+// 示例:
+//
+//	m := map[string][]int{"a": {1}}
+//	v := GetOrElse(m, "b", func() []int { return make([]int, 0, 8) })
+//	// v = []int{}
+func GetOrElse[K comparable, V any](m map[K]V, key K, def func() V) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return def()
+}
+
+// Equal 判断两个 map 是否包含完全相同的键集合且对应的值相等。
+//
+// nil map 与空 map 视为相等。
+//
+// 示例:
+//
+//	Equal(map[string]int{"a": 1}, map[string]int{"a": 1})
+//	// true
+func Equal[K, V comparable](a, b map[K]V) bool {
+	return EqualFunc(a, b, func(x, y V) bool { return x == y })
+}
+
+// EqualFunc 判断两个 map 是否包含完全相同的键集合，并使用 eq 比较对应的值。
+//
+// 适用于值类型不可比较（如切片、包含切片的结构体）的场景。
+// nil map 与空 map 视为相等。
+//
+// 示例:
+//
+//	a := map[string][]int{"a": {1, 2}}
+//	b := map[string][]int{"a": {1, 2}}
+//	EqualFunc(a, b, slices.Equal[[]int])
+//	// true
+func EqualFunc[K comparable, V any](a, b map[K]V, eq func(V, V) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || !eq(va, vb) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone 返回 map 的浅拷贝。
+//
+// 仅复制键值本身，若值为指针、切片或 map，拷贝与源 map 仍共享底层数据；
+// 如需深拷贝请使用 DeepClone。传入 nil 时返回 nil。
+//
+// 示例:
+//
+//	src := map[string]int{"a": 1}
+//	dst := Clone(src)
+//	dst["a"] = 2
+//	// src["a"] = 1
+func Clone[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]V, len(m))
+	for k, v := range m {
+		r[k] = v
+	}
+	return r
+}
+
+// DeepClone 返回 map 的拷贝，每个值都经过 copyVal 复制。
+//
+// 参数:
+//   - m: 源 map，传入 nil 时返回 nil
+//   - copyVal: 值复制函数，负责为指针、切片等引用类型的值生成独立副本
+//
+// 示例:
+//
+//	src := map[string][]int{"a": {1, 2}}
+//	dst := DeepClone(src, func(s []int) []int { return append([]int(nil), s...) })
+//	dst["a"][0] = 100
+//	// src["a"][0] = 1
+func DeepClone[K comparable, V any](m map[K]V, copyVal func(V) V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]V, len(m))
+	for k, v := range m {
+		r[k] = copyVal(v)
+	}
+	return r
+}
+
+// Pick 返回只包含指定键的新 map。
+//
+// 源 map 中不存在的键会被忽略；源 map 不会被修改。
+// 传入 nil map 时返回空 map（非 nil）。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2, "c": 3}
+//	r := Pick(m, "a", "c", "x")
+//	// r = map[string]int{"a": 1, "c": 3}
+func Pick[K comparable, V any](m map[K]V, keys ...K) map[K]V {
+	r := make(map[K]V, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// Omit 返回移除指定键后的新 map。
+//
+// 源 map 不会被修改。传入 nil map 时返回空 map（非 nil）。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2, "c": 3}
+//	r := Omit(m, "b")
+//	// r = map[string]int{"a": 1, "c": 3}
+func Omit[K comparable, V any](m map[K]V, keys ...K) map[K]V {
+	r := make(map[K]V, len(m))
+	for k, v := range m {
+		r[k] = v
+	}
+	for _, k := range keys {
+		delete(r, k)
+	}
+	return r
+}
+
+// Reduce 将 map 的所有条目折叠为单个值。
+//
+// 由于 map 的遍历顺序不确定，fn 必须与遍历顺序无关（如求和、计数），
+// 否则结果可能不稳定；对顺序敏感的折叠请使用 ReduceSorted。
+//
+// 参数:
+//   - m: 源 map，可以为 nil
+//   - init: 累加器初始值
+//   - fn: 折叠函数，接收当前累加值和条目，返回新的累加值
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	sum := Reduce(m, 0, func(acc int, _ string, v int) int { return acc + v })
+//	// sum = 3
+func Reduce[K comparable, V any, R any](m map[K]V, init R, fn func(acc R, k K, v V) R) R {
+	acc := init
+	for k, v := range m {
+		acc = fn(acc, k, v)
+	}
+	return acc
+}
+
+// ReduceSorted 按键升序遍历 map，将所有条目折叠为单个值。
+//
+// 适用于结果依赖遍历顺序的折叠，如字符串拼接。
+//
+// 示例:
+//
+//	m := map[string]int{"b": 2, "a": 1}
+//	s := ReduceSorted(m, "", func(acc string, k string, _ int) string { return acc + k })
+//	// s = "ab"
+func ReduceSorted[K cmp.Ordered, V any, R any](m map[K]V, init R, fn func(acc R, k K, v V) R) R {
+	acc := init
+	for _, k := range sortedKeys(m) {
+		acc = fn(acc, k, m[k])
+	}
+	return acc
+}
+
+// sortedKeys 返回 m 中按升序排列的所有键。
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Any 判断 map 中是否存在满足 pred 的条目。
+//
+// 遇到第一个满足条件的条目后立即返回。空 map 或 nil map 返回 false。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": -1}
+//	Any(m, func(_ string, v int) bool { return v < 0 })
+//	// true
+func Any[K comparable, V any](m map[K]V, pred func(K, V) bool) bool {
+	for k, v := range m {
+		if pred(k, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// All 判断 map 中的所有条目是否都满足 pred。
+//
+// 遇到第一个不满足条件的条目后立即返回。空 map 或 nil map 返回 true。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	All(m, func(_ string, v int) bool { return v > 0 })
+//	// true
+func All[K comparable, V any](m map[K]V, pred func(K, V) bool) bool {
+	for k, v := range m {
+		if !pred(k, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// None 判断 map 中是否没有任何条目满足 pred。
+//
+// 遇到第一个满足条件的条目后立即返回。空 map 或 nil map 返回 true。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	None(m, func(_ string, v int) bool { return v < 0 })
+//	// true
+func None[K comparable, V any](m map[K]V, pred func(K, V) bool) bool {
+	return !Any(m, pred)
+}
+
+// Find 返回 map 中第一个满足 pred 的条目。
+//
+// 由于 map 的遍历顺序不确定，多个条目满足条件时返回哪一个是不确定的；
+// 需要确定性结果时请使用 FindSorted。
+//
+// 返回值:
+//   - 满足条件的键和值；若没有条目满足条件，返回零值
+//   - 是否找到满足条件的条目
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	k, v, ok := Find(m, func(_ string, v int) bool { return v > 1 })
+//	// k = "b", v = 2, ok = true
+func Find[K comparable, V any](m map[K]V, pred func(K, V) bool) (K, V, bool) {
+	for k, v := range m {
+		if pred(k, v) {
+			return k, v, true
+		}
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+// FindSorted 按键升序遍历 map，返回第一个满足 pred 的条目。
+//
+// 示例:
+//
+//	m := map[string]int{"b": 2, "a": 1, "c": 3}
+//	k, v, ok := FindSorted(m, func(_ string, v int) bool { return v > 1 })
+//	// k = "b", v = 2, ok = true
+func FindSorted[K cmp.Ordered, V any](m map[K]V, pred func(K, V) bool) (K, V, bool) {
+	for _, k := range sortedKeys(m) {
+		if v := m[k]; pred(k, v) {
+			return k, v, true
+		}
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+// Count 返回 map 中满足 pred 的条目数量。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2, "c": 3}
+//	n := Count(m, func(_ string, v int) bool { return v > 1 })
+//	// n = 2
+func Count[K comparable, V any](m map[K]V, pred func(K, V) bool) int {
+	n := 0
+	for k, v := range m {
+		if pred(k, v) {
+			n++
+		}
+	}
+	return n
+}
+
+// CountBy 统计切片中每个派生键出现的次数，生成频次表。
+//
+// 参数:
+//   - list: 源切片
+//   - key: 键提取函数，用于从切片元素中派生统计键
+//
+// 返回值:
+//   - 键到出现次数的 map；空切片或 nil 切片返回空 map（非 nil）
+//
+// 示例:
+//
+//	list := []string{"apple", "avocado", "banana"}
+//	m := CountBy(list, func(s string) byte { return s[0] })
+//	// m = map[byte]int{'a': 2, 'b': 1}
+func CountBy[T any, K comparable](list []T, key func(T) K) map[K]int {
+	m := make(map[K]int)
+	for _, v := range list {
+		m[key(v)]++
+	}
+	return m
+}
+
+// MapToSlice 将 map 转换为切片，通过 f 将每个条目转换为切片元素。
+//
+// 返回切片的元素顺序不确定（依赖 map 遍历顺序）；需要确定顺序时请使用 MapToSliceSorted。
+// 传入 nil map 时返回空切片（非 nil）。
+//
+// 示例:
+//
+//	m := map[int]string{1: "Alice", 2: "Bob"}
+//	users := MapToSlice(m, func(id int, name string) User { return User{ID: id, Name: name} })
+//	// len(users) = 2
+func MapToSlice[K comparable, V any, R any](m map[K]V, f func(K, V) R) []R {
+	r := make([]R, 0, len(m))
+	for k, v := range m {
+		r = append(r, f(k, v))
+	}
+	return r
+}
+
+// MapToSliceSorted 按键升序将 map 转换为切片。
+//
+// 示例:
+//
+//	m := map[int]string{2: "Bob", 1: "Alice"}
+//	names := MapToSliceSorted(m, func(_ int, name string) string { return name })
+//	// names = []string{"Alice", "Bob"}
+func MapToSliceSorted[K cmp.Ordered, V any, R any](m map[K]V, f func(K, V) R) []R {
+	r := make([]R, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		r = append(r, f(k, m[k]))
+	}
+	return r
+}
+
+// Intersect 返回键同时存在于 a 和 b 中的条目，值取自 a。
+//
+// 源 map 不会被修改；返回的 map 始终非 nil。
+//
+// 示例:
+//
+//	a := map[string]int{"a": 1, "b": 2}
+//	b := map[string]int{"b": 20, "c": 30}
+//	r := Intersect(a, b)
+//	// r = map[string]int{"b": 2}
+func Intersect[K comparable, V any](a, b map[K]V) map[K]V {
+	r := make(map[K]V)
+	for k, v := range a {
+		if _, ok := b[k]; ok {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// Union 返回 a 和 b 所有键的并集，键冲突时 b 的值优先。
+//
+// 源 map 不会被修改；返回的 map 始终非 nil。
+//
+// 示例:
+//
+//	a := map[string]int{"a": 1, "b": 2}
+//	b := map[string]int{"b": 20, "c": 30}
+//	r := Union(a, b)
+//	// r = map[string]int{"a": 1, "b": 20, "c": 30}
+func Union[K comparable, V any](a, b map[K]V) map[K]V {
+	r := make(map[K]V, len(a)+len(b))
+	for k, v := range a {
+		r[k] = v
+	}
+	for k, v := range b {
+		r[k] = v
+	}
+	return r
+}
+
+// Subtract 返回 a 中键不存在于 b 的条目。
+//
+// 源 map 不会被修改；返回的 map 始终非 nil。
+//
+// 示例:
+//
+//	a := map[string]int{"a": 1, "b": 2}
+//	b := map[string]int{"b": 20, "c": 30}
+//	r := Subtract(a, b)
+//	// r = map[string]int{"a": 1}
+func Subtract[K comparable, V any](a, b map[K]V) map[K]V {
+	r := make(map[K]V)
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// MapByWith 将切片转换为 map，并通过 resolve 决定键冲突时保留的值。
+//
+// 参数:
+//   - list: 源切片
+//   - key: 键提取函数
+//   - value: 值提取函数
+//   - resolve: 冲突解决函数，existing 为 map 中已有的值，incoming 为新元素的值，
+//     返回值将作为该键最终保存的值；传入 nil 时后者覆盖前者，与 MapBy 行为一致
+//
+// 示例:
+//
+//	orders := []Order{{UserID: 1, Amount: 10}, {UserID: 1, Amount: 20}}
+//	m := MapByWith(orders,
+//	    func(o Order) int { return o.UserID },
+//	    func(o Order) int { return o.Amount },
+//	    func(existing, incoming int) int { return existing + incoming },
+//	)
+//	// m = map[int]int{1: 30}
+func MapByWith[T any, K comparable, V any](list []T, key func(T) K, value func(T) V, resolve func(existing, incoming V) V) map[K]V {
+	if resolve == nil {
+		return MapBy(list, key, value)
+	}
+	m := make(map[K]V, len(list))
+	for _, item := range list {
+		k, v := key(item), value(item)
+		if existing, ok := m[k]; ok {
+			v = resolve(existing, v)
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// MinKey 返回 map 中最小的键。
+//
+// 返回值:
+//   - 第一个返回值为最小的键，map 为空时返回零值
+//   - 第二个返回值表示 map 是否非空
+//
+// 示例:
+//
+//	k, ok := MinKey(map[int]string{3: "c", 1: "a"})
+//	// k = 1, ok = true
+func MinKey[K cmp.Ordered, V any](m map[K]V) (K, bool) {
+	var r K
+	first := true
+	for k := range m {
+		if first || k < r {
+			r = k
+			first = false
+		}
+	}
+	return r, !first
+}
+
+// MaxKey 返回 map 中最大的键。
+//
+// 返回值:
+//   - 第一个返回值为最大的键，map 为空时返回零值
+//   - 第二个返回值表示 map 是否非空
+//
+// 示例:
+//
+//	k, ok := MaxKey(map[int]string{3: "c", 1: "a"})
+//	// k = 3, ok = true
+func MaxKey[K cmp.Ordered, V any](m map[K]V) (K, bool) {
+	var r K
+	first := true
+	for k := range m {
+		if first || k > r {
+			r = k
+			first = false
+		}
+	}
+	return r, !first
+}
+
+// MinValueBy 按 less 比较值，返回值最小的条目。
+//
+// 多个条目的值并列最小时，返回其中任意一个。
+//
+// 参数:
+//   - m: 源 map
+//   - less: 比较函数，a 小于 b 时返回 true
+//
+// 返回值:
+//   - 值最小的条目的键和值，map 为空时返回零值
+//   - map 是否非空
+//
+// 示例:
+//
+//	scores := map[string]int{"alice": 90, "bob": 75}
+//	name, score, ok := MinValueBy(scores, func(a, b int) bool { return a < b })
+//	// name = "bob", score = 75, ok = true
+func MinValueBy[K comparable, V any](m map[K]V, less func(V, V) bool) (K, V, bool) {
+	var rk K
+	var rv V
+	first := true
+	for k, v := range m {
+		if first || less(v, rv) {
+			rk, rv = k, v
+			first = false
+		}
+	}
+	return rk, rv, !first
+}
+
+// MaxValueBy 按 less 比较值，返回值最大的条目。
+//
+// 多个条目的值并列最大时，返回其中任意一个。
+//
+// 示例:
+//
+//	scores := map[string]int{"alice": 90, "bob": 75}
+//	name, score, ok := MaxValueBy(scores, func(a, b int) bool { return a < b })
+//	// name = "alice", score = 90, ok = true
+func MaxValueBy[K comparable, V any](m map[K]V, less func(V, V) bool) (K, V, bool) {
+	return MinValueBy(m, func(a, b V) bool { return less(b, a) })
+}
+
+// Number 是可参与求和运算的数值类型约束，包含所有整数和浮点数类型（含以其为底层类型的自定义类型）。
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum 返回 map 中所有值的和，空 map 或 nil map 返回零值。
+//
+// 示例:
+//
+//	Sum(map[string]int{"a": 1, "b": 2})
+//	// 3
+func Sum[K comparable, V Number](m map[K]V) V {
+	var sum V
+	for _, v := range m {
+		sum += v
+	}
+	return sum
+}
+
+// SumBy 对切片中每个元素派生的数值求和，空切片或 nil 切片返回零值。
+//
+// 示例:
+//
+//	items := []Item{{Price: 1.5}, {Price: 2.5}}
+//	total := SumBy(items, func(i Item) float64 { return i.Price })
+//	// total = 4.0
+func SumBy[T any, N Number](list []T, f func(T) N) N {
+	var sum N
+	for _, v := range list {
+		sum += f(v)
+	}
+	return sum
+}
+
+// GetMany 一次性获取多个键的值。
+//
+// 参数:
+//   - m: 源 map，可以为 nil（此时所有键都视为缺失）
+//   - keys: 要查找的键
+//
+// 返回值:
+//   - found: 存在的键及其值，始终非 nil
+//   - missing: 不存在的键，按 keys 中的顺序排列；全部存在时为 nil
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	found, missing := GetMany(m, "a", "x")
+//	// found = map[string]int{"a": 1}, missing = []string{"x"}
+func GetMany[K comparable, V any](m map[K]V, keys ...K) (found map[K]V, missing []K) {
+	found = make(map[K]V, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			found[k] = v
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	return found, missing
+}
+
+// GetManyAs 一次性获取多个键的值，并通过 f 转换每个存在的值。
+//
+// 不存在的键会被忽略。返回的 map 始终非 nil。
+//
+// 示例:
+//
+//	users := map[int]User{1: {Name: "Alice"}, 2: {Name: "Bob"}}
+//	names := GetManyAs(users, []int{1, 3}, func(u User) string { return u.Name })
+//	// names = map[int]string{1: "Alice"}
+func GetManyAs[T any, K comparable, V any](m map[K]T, keys []K, f func(T) V) map[K]V {
+	r := make(map[K]V, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			r[k] = f(v)
+		}
+	}
+	return r
+}
+
+// Partition 按 pred 将 map 拆分为两个新 map。
+//
+// 返回值:
+//   - matched: 满足 pred 的条目
+//   - rest: 不满足 pred 的条目
+//
+// 两个返回值始终非 nil；源 map 不会被修改。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2, "c": 3}
+//	odd, even := Partition(m, func(_ string, v int) bool { return v%2 == 1 })
+//	// odd = map[string]int{"a": 1, "c": 3}, even = map[string]int{"b": 2}
+func Partition[K comparable, V any](m map[K]V, pred func(K, V) bool) (matched, rest map[K]V) {
+	matched = make(map[K]V)
+	rest = make(map[K]V)
+	for k, v := range m {
+		if pred(k, v) {
+			matched[k] = v
+		} else {
+			rest[k] = v
+		}
+	}
+	return matched, rest
+}
+
+// SameKeys 判断两个 map 是否具有完全相同的键集合，忽略值的差异。
+//
+// 两个 map 的值类型可以不同。nil map 与空 map 视为相等。
+//
+// 示例:
+//
+//	a := map[string]int{"a": 1, "b": 2}
+//	b := map[string]bool{"a": true, "b": false}
+//	SameKeys(a, b)
+//	// true
+func SameKeys[K comparable, V1, V2 any](a map[K]V1, b map[K]V2) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// MapByUnique 将切片转换为 map，要求每个元素产生的键唯一。
+//
+// 适用于主键等预期唯一的数据：与 MapBy 静默覆盖不同，出现重复键时返回错误，
+// 错误信息中包含重复的键以及产生冲突的两个元素下标。
+//
+// 可能返回的错误:
+//   - ErrDuplicateKey: 两个元素产生了相同的键
+//
+// 示例:
+//
+//	users := []User{{ID: 1, Name: "Alice"}, {ID: 1, Name: "Bob"}}
+//	_, err := MapByUnique(users, func(u User) int { return u.ID }, func(u User) string { return u.Name })
+//	// errors.Is(err, ErrDuplicateKey) = true
+func MapByUnique[T any, K comparable, V any](list []T, key func(T) K, value func(T) V) (map[K]V, error) {
+	m := make(map[K]V, len(list))
+	seen := make(map[K]int, len(list))
+	for i, item := range list {
+		k := key(item)
+		if j, ok := seen[k]; ok {
+			return nil, NewErrDuplicateKey(k, j, i)
+		}
+		seen[k] = i
+		m[k] = value(item)
+	}
+	return m, nil
+}
+
+// Chunk 将 map 拆分为若干个最多包含 size 个条目的子 map，适用于批量处理。
+//
+// 每个条目恰好出现在一个子 map 中；条目在各子 map 间的分布不确定（依赖 map 遍历顺序）。
+// size <= 0 时不做拆分，返回只包含一个完整拷贝的切片。
+// 空 map 或 nil map 返回空切片（非 nil）。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2, "c": 3}
+//	chunks := Chunk(m, 2)
+//	// len(chunks) = 2，分别包含 2 个和 1 个条目
+func Chunk[K comparable, V any](m map[K]V, size int) []map[K]V {
+	if len(m) == 0 {
+		return []map[K]V{}
+	}
+	if size <= 0 {
+		return []map[K]V{Clone(m)}
+	}
+	chunks := make([]map[K]V, 0, (len(m)+size-1)/size)
+	var cur map[K]V
+	for k, v := range m {
+		if len(cur) == 0 {
+			cur = make(map[K]V, min(size, len(m)-len(chunks)*size))
+			chunks = append(chunks, cur)
+		}
+		cur[k] = v
+		if len(cur) == size {
+			cur = nil
+		}
+	}
+	return chunks
+}
+
+// Rekey 通过 f 转换所有键，返回新的 map。
+//
+// 若多个源键被转换为相同的新键，后遍历到的条目覆盖前者（由于 map 遍历顺序不确定，
+// 最终保留哪个值也不确定）；需要检测冲突时请使用 RekeyUnique。
+// 传入 nil map 时返回空 map（非 nil）。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	r := Rekey(m, func(k string) string { return "prefix_" + k })
+//	// r = map[string]int{"prefix_a": 1, "prefix_b": 2}
+func Rekey[K1 comparable, K2 comparable, V any](m map[K1]V, f func(K1) K2) map[K2]V {
+	r := make(map[K2]V, len(m))
+	for k, v := range m {
+		r[f(k)] = v
+	}
+	return r
+}
+
+// RekeyUnique 通过 f 转换所有键，要求转换后的键互不相同。
+//
+// 可能返回的错误:
+//   - ErrDuplicateKey: 两个源键被转换为相同的新键
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "A": 2}
+//	_, err := RekeyUnique(m, strings.ToLower)
+//	// errors.Is(err, ErrDuplicateKey) = true
+func RekeyUnique[K1 comparable, K2 comparable, V any](m map[K1]V, f func(K1) K2) (map[K2]V, error) {
+	r := make(map[K2]V, len(m))
+	from := make(map[K2]K1, len(m))
+	for k, v := range m {
+		nk := f(k)
+		if prev, ok := from[nk]; ok {
+			return nil, fmt.Errorf("key %v produced by source keys %v and %v: %w", nk, prev, k, ErrDuplicateKey)
+		}
+		from[nk] = k
+		r[nk] = v
+	}
+	return r, nil
+}
+
+// MapByFiltered 将切片中满足 include 的元素转换为 map。
+//
+// include 返回 false 的元素会被跳过，不会调用 key 和 value。
+// include 为 nil 时包含所有元素，与 MapBy 行为一致。若多个元素产生相同的键，后者会覆盖前者。
+//
+// 示例:
+//
+//	users := []User{{ID: 1, Active: true}, {ID: 2, Active: false}}
+//	m := MapByFiltered(users,
+//	    func(u User) bool { return u.Active },
+//	    func(u User) int { return u.ID },
+//	    func(u User) User { return u },
+//	)
+//	// m 只包含 ID 为 1 的用户
+func MapByFiltered[T any, K comparable, V any](list []T, include func(T) bool, key func(T) K, value func(T) V) map[K]V {
+	if include == nil {
+		return MapBy(list, key, value)
+	}
+	m := make(map[K]V)
+	for _, v := range list {
+		if include(v) {
+			m[key(v)] = value(v)
+		}
+	}
+	return m
+}
+
+// Update 根据键的当前值及其是否存在计算新值，并原地写回 m。
+//
+// 适用于"存在则累加，不存在则初始化"一类的场景。
+// m 必须非 nil，对 nil map 调用会像普通 map 写入一样触发 panic。
+//
+// 参数:
+//   - m: 目标 map
+//   - key: 要更新的键
+//   - f: 计算函数，old 为当前值（不存在时为零值），existed 表示键是否存在
+//
+// 示例:
+//
+//	counter := map[string]int{"a": 1}
+//	Update(counter, "a", func(old int, _ bool) int { return old + 1 })
+//	Update(counter, "b", func(old int, existed bool) int { return 100 })
+//	// counter = map[string]int{"a": 2, "b": 100}
+func Update[K comparable, V any](m map[K]V, key K, f func(old V, existed bool) V) {
+	old, ok := m[key]
+	m[key] = f(old, ok)
+}
+
+// Delete 从 m 中删除多个键，不存在的键会被忽略。
+//
+// 对 nil map 调用是安全的。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2, "c": 3}
+//	Delete(m, "a", "c", "x")
+//	// m = map[string]int{"b": 2}
+func Delete[K comparable, V any](m map[K]V, keys ...K) {
+	for _, k := range keys {
+		delete(m, k)
+	}
+}
+
+// MapGet2 从 map 中获取值，同时返回原始值和转换后的值。
+//
+// 与 MapGet 不同，调用方无需再次查找即可拿到原始值。
+//
+// 参数:
+//   - m: 源 map
+//   - key: 要查找的键
+//   - value: 值转换函数；传入 nil 时 transformed 为零值，raw 仍会正常返回
+//
+// 返回值:
+//   - raw: map 中的原始值，key 不存在时为零值
+//   - transformed: 转换后的值，key 不存在或 value 为 nil 时为零值
+//   - ok: key 是否存在于 map 中
+//
+// 示例:
+//
+//	users := map[int]User{1: {Name: "Alice"}}
+//	u, name, ok := MapGet2(users, 1, func(u User) string { return u.Name })
+//	// u = User{Name: "Alice"}, name = "Alice", ok = true
+func MapGet2[T any, K comparable, V any](m map[K]T, key K, value func(T) V) (raw T, transformed V, ok bool) {
+	raw, ok = m[key]
+	if ok && value != nil {
+		transformed = value(raw)
+	}
+	return raw, transformed, ok
+}
+
+// MapByReport 与 MapBy 一样将切片转换为 map（相同键后者覆盖前者），同时返回被覆盖而丢弃的元素。
+//
+// 适用于数据导入等场景，需要保留 MapBy 的覆盖语义，又要记录重复数据以便排查。
+//
+// 参数:
+//   - list: 源切片
+//   - key: 键提取函数
+//   - value: 值提取函数
+//
+// 返回值:
+//   - m: 由切片元素构建的 map，与 MapBy 的结果相同
+//   - dropped: 键被后续元素覆盖的元素，按输入顺序排列；没有重复时为 nil
+//
+// 示例:
+//
+//	users := []User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}, {ID: 1, Name: "Carol"}}
+//	m, dropped := MapByReport(users, func(u User) int { return u.ID }, func(u User) string { return u.Name })
+//	// m = map[int]string{1: "Carol", 2: "Bob"}
+//	// dropped = []User{{ID: 1, Name: "Alice"}}
+func MapByReport[T any, K comparable, V any](list []T, key func(T) K, value func(T) V) (m map[K]V, dropped []T) {
+	m = make(map[K]V, len(list))
+	last := make(map[K]int, len(list))
+	var overwritten []bool
+	for i, item := range list {
+		k := key(item)
+		if j, ok := last[k]; ok {
+			if overwritten == nil {
+				overwritten = make([]bool, len(list))
+			}
+			overwritten[j] = true
+		}
+		last[k] = i
+		m[k] = value(item)
+	}
+	for i, item := range list {
+		if overwritten != nil && overwritten[i] {
+			dropped = append(dropped, item)
+		}
+	}
+	return m, dropped
+}
+
+// LoadOrStore 返回 m 中 key 对应的已有值；若不存在，则调用 compute 计算新值，写入 m 后返回。
+//
+// compute 仅在 key 不存在时调用一次。与 SafeMap.LoadOrStore 不同，该函数不是并发安全的，
+// 多个 goroutine 同时访问同一个 map 时需要调用方自行加锁。
+// m 必须非 nil，传入 nil map 会触发 panic。
+//
+// 参数:
+//   - m: 目标 map，原地修改
+//   - key: 要查找的键
+//   - compute: 键不存在时用于计算新值的函数
+//
+// 返回值:
+//   - V: 已有值或新计算并写入的值
+//   - bool: true 表示返回的是已有值，false 表示新写入的值
+//
+// 示例:
+//
+//	cache := map[string]int{"a": 1}
+//	v, loaded := LoadOrStore(cache, "a", func() int { return 100 }) // v = 1, loaded = true
+//	v, loaded = LoadOrStore(cache, "b", func() int { return 2 })    // v = 2, loaded = false
+func LoadOrStore[K comparable, V any](m map[K]V, key K, compute func() V) (V, bool) {
+	if m == nil {
+		panic("maputil: LoadOrStore called with nil map")
+	}
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	v := compute()
+	m[key] = v
+	return v, false
+}
+
+// MergeInto 将 srcs 中的所有条目原地合并到 dst，键冲突时靠后的源优先。
+//
+// 与 Union 不同，MergeInto 不分配新的 map，适合循环中不断累积结果的场景。
+// dst 必须非 nil（srcs 均为空时除外）；srcs 不会被修改，nil 源会被跳过。
+//
+// 示例:
+//
+//	dst := map[string]int{"a": 1}
+//	MergeInto(dst, map[string]int{"a": 10, "b": 2}, map[string]int{"b": 20})
+//	// dst = map[string]int{"a": 10, "b": 20}
+func MergeInto[K comparable, V any](dst map[K]V, srcs ...map[K]V) {
+	for _, src := range srcs {
+		for k, v := range src {
+			dst[k] = v
+		}
+	}
+}
+
+// MergeIntoFunc 与 MergeInto 相同，但键冲突时调用 resolve 决定最终值。
+//
+// resolve 的 existing 为 dst 中的当前值，incoming 为当前源中的值；
+// 键不冲突时直接写入，不调用 resolve。dst 必须非 nil（srcs 均为空时除外）。
+//
+// 示例:
+//
+//	dst := map[string]int{"a": 1}
+//	MergeIntoFunc(dst, func(_ string, existing, incoming int) int { return existing + incoming },
+//	    map[string]int{"a": 10, "b": 2}, map[string]int{"b": 20})
+//	// dst = map[string]int{"a": 11, "b": 22}
+func MergeIntoFunc[K comparable, V any](dst map[K]V, resolve func(key K, existing, incoming V) V, srcs ...map[K]V) {
+	for _, src := range srcs {
+		for k, v := range src {
+			if old, ok := dst[k]; ok {
+				v = resolve(k, old, v)
+			}
+			dst[k] = v
+		}
+	}
+}
+
+// FilterMap 在一次遍历中同时完成过滤和值转换。
+//
+// f 返回转换后的值及是否保留该条目，返回 false 的条目会被丢弃。
+// 源 map 不会被修改；返回的 map 始终非 nil。
+//
+// 示例:
+//
+//	m := map[string]int{"a": 1, "b": 2, "c": 3}
+//	r := FilterMap(m, func(_ string, v int) (string, bool) {
+//	    return strconv.Itoa(v * 10), v%2 == 1
+//	})
+//	// r = map[string]string{"a": "10", "c": "30"}
+func FilterMap[K comparable, V, R any](m map[K]V, f func(K, V) (R, bool)) map[K]R {
+	r := make(map[K]R)
+	for k, v := range m {
+		if nv, ok := f(k, v); ok {
+			r[k] = nv
+		}
+	}
+	return r
+}
+
+// MapByCap 与 MapBy 相同，但使用 capacity 作为结果 map 的初始容量。
+//
+// MapBy 按 len(list) 预分配容量：当大量元素的键重复时会过度分配内存；
+// 而调用方若已知去重后的键数量，也可以借此避免扩容。capacity 只是容量提示，
+// 实际条目数超出时 map 会自动扩容；capacity 小于 0 时按 0 处理。
+//
+// 参数:
+//   - list: 源切片
+//   - capacity: 结果 map 的初始容量，通常为预估的不同键数量
+//   - key: 键提取函数
+//   - value: 值提取函数
+//
+// 示例:
+//
+//	// 百万条订单只属于约 100 个商户
+//	m := MapByCap(orders, 100, func(o Order) int { return o.MerchantID }, func(o Order) Order { return o })
+func MapByCap[T any, K comparable, V any](list []T, capacity int, key func(T) K, value func(T) V) map[K]V {
+	m := make(map[K]V, max(capacity, 0))
+	for _, v := range list {
+		m[key(v)] = value(v)
+	}
+	return m
+}
+
+// RangeSortedE 按键升序遍历 map，对每个条目调用 fn，遇到第一个错误时立即停止并返回该错误。
+//
+// 适用于处理顺序重要且出错需要立即中止的场景，例如按版本号依次执行迁移。
+//
+// 返回值:
+//   - nil: 所有条目处理成功（空 map 或 nil map 同样返回 nil）
+//   - error: fn 返回的第一个错误，之后的条目不会再被处理
+//
+// 示例:
+//
+//	migrations := map[int]func() error{1: createUsers, 2: addEmailIndex}
+//	err := RangeSortedE(migrations, func(version int, apply func() error) error {
+//	    return apply()
+//	})
+func RangeSortedE[K cmp.Ordered, V any](m map[K]V, fn func(K, V) error) error {
+	for _, k := range sortedKeys(m) {
+		if err := fn(k, m[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MapByIndexed 与 MapBy 相同，但同时将元素在切片中的下标传给键和值提取函数。
+//
+// 适用于需要根据元素位置生成键或值的场景（如分配序号），避免在闭包中手动维护计数器。
+// 若多个元素产生相同的键，后者会覆盖前者。
+//
+// 参数:
+//   - list: 源切片
+//   - key: 键提取函数，i 为元素下标
+//   - value: 值提取函数，i 为元素下标
+//
+// 示例:
+//
+//	names := []string{"alice", "bob"}
+//	m := MapByIndexed(names, func(_ int, s string) string { return s }, func(i int, _ string) int { return i })
+//	// m = map[string]int{"alice": 0, "bob": 1}
+func MapByIndexed[T any, K comparable, V any](list []T, key func(i int, v T) K, value func(i int, v T) V) map[K]V {
+	m := make(map[K]V, len(list))
+	for i, v := range list {
+		m[key(i, v)] = value(i, v)
+	}
+	return m
+}
+
+// MapByOrdered 与 MapBy 一样将切片转换为 map（相同键后者覆盖前者），同时返回键首次出现的顺序。
+//
+// 适用于需要按输入顺序稳定输出、又不希望额外排序的场景。
+//
+// 返回值:
+//   - map[K]V: 由切片元素构建的 map，与 MapBy 的结果相同
+//   - []K: 去重后的键，按首次出现的顺序排列；空切片或 nil 切片返回空切片（非 nil）
+//
+// 示例:
+//
+//	list := []string{"banana", "apple", "blueberry"}
+//	m, order := MapByOrdered(list, func(s string) string { return s[:1] }, func(s string) string { return s })
+//	// m = map[string]string{"b": "blueberry", "a": "apple"}
+//	// order = []string{"b", "a"}
+func MapByOrdered[T any, K comparable, V any](list []T, key func(T) K, value func(T) V) (map[K]V, []K) {
+	m := make(map[K]V, len(list))
+	order := make([]K, 0, len(list))
+	for _, item := range list {
+		k := key(item)
+		if _, ok := m[k]; !ok {
+			order = append(order, k)
+		}
+		m[k] = value(item)
+	}
+	return m, order
+}
+
+// GetFirst 按给定顺序查找 keys，返回第一个存在的键对应的值。
+//
+// 适用于配置项存在别名或旧键名的场景，避免一连串的 if v, ok := m[...] 判断。
+//
+// 返回值:
+//   - V: 第一个存在的键对应的值，均不存在时返回零值
+//   - bool: 是否找到任意一个键
+//
+// 示例:
+//
+//	cfg := map[string]string{"db_host": "127.0.0.1"}
+//	host, ok := GetFirst(cfg, "host", "db_host", "mysql_host")
+//	// host = "127.0.0.1", ok = true
+func GetFirst[K comparable, V any](m map[K]V, keys ...K) (V, bool) {
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Clear 删除 m 中的所有条目，保留已分配的容量以便复用，等价于内置函数 clear。
+//
+// 适用于热点循环中反复填充同一个 map 的场景。m 为 nil 时不做任何操作。
+func Clear[K comparable, V any](m map[K]V) {
+	clear(m)
+}
+
+// Drain 返回 m 当前内容的拷贝，并清空 m（保留其容量）。
+//
+// 适用于"取走当前批次、继续累积下一批"的场景。
+// 返回的 map 始终非 nil；该函数不是并发安全的。
+//
+// 示例:
+//
+//	pending := map[string]int{"a": 1, "b": 2}
+//	batch := Drain(pending)
+//	// batch = map[string]int{"a": 1, "b": 2}, len(pending) = 0
+func Drain[K comparable, V any](m map[K]V) map[K]V {
+	r := make(map[K]V, len(m))
+	for k, v := range m {
+		r[k] = v
+	}
+	clear(m)
+	return r
+}
+
+// MapBy2 将切片转换为两级嵌套的 map，适用于构建 map[region]map[id]User 这类嵌套索引。
+//
+// 内层 map 在首次遇到对应的外层键时创建。
+//
+// 参数:
+//   - list: 源切片
+//   - key1: 外层键提取函数
+//   - key2: 内层键提取函数
+//   - value: 值提取函数
+//
+// 返回值:
+//   - 由切片元素构建的两级 map
+//
+// 注意: 若多个元素产生相同的 (key1, key2)，后者会覆盖前者。
+//
+// 示例:
+//
+//	users := []User{{Region: "cn", ID: 1}, {Region: "us", ID: 2}, {Region: "cn", ID: 3}}
+//	m := MapBy2(users, func(u User) string { return u.Region }, func(u User) int { return u.ID }, func(u User) User { return u })
+//	// m = map[string]map[int]User{"cn": {1: ..., 3: ...}, "us": {2: ...}}
+func MapBy2[T any, K1, K2 comparable, V any](list []T, key1 func(T) K1, key2 func(T) K2, value func(T) V) map[K1]map[K2]V {
+	m := make(map[K1]map[K2]V)
+	for _, item := range list {
+		k1 := key1(item)
+		inner, ok := m[k1]
+		if !ok {
+			inner = make(map[K2]V)
+			m[k1] = inner
+		}
+		inner[key2(item)] = value(item)
+	}
+	return m
+}
+
+// Index 以 key 提取的字段为键，将切片元素本身作为值构建索引，等价于值函数为恒等函数的 MapBy。
+//
+// 注意: 若多个元素产生相同的键，后者会覆盖前者。
+//
+// 示例:
+//
+//	users := []User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}
+//	byID := Index(users, func(u User) int { return u.ID })
+//	// byID = map[int]User{1: {ID: 1, Name: "Alice"}, 2: {ID: 2, Name: "Bob"}}
+func Index[T any, K comparable](list []T, key func(T) K) map[K]T {
+	m := make(map[K]T, len(list))
+	for _, v := range list {
+		m[key(v)] = v
+	}
+	return m
+}
+
+// IndexUnique 与 Index 相同，但要求每个元素产生的键唯一。
+//
+// 可能返回的错误:
+//   - ErrDuplicateKey: 两个元素产生了相同的键
+//
+// 示例:
+//
+//	users := []User{{ID: 1, Name: "Alice"}, {ID: 1, Name: "Bob"}}
+//	_, err := IndexUnique(users, func(u User) int { return u.ID })
+//	// errors.Is(err, ErrDuplicateKey) = true
+func IndexUnique[T any, K comparable](list []T, key func(T) K) (map[K]T, error) {
+	return MapByUnique(list, key, func(v T) T { return v })
+}
+
+// CompactPtr 返回一个新 map，去除 m 中值为 nil 指针的条目。
+//
+// m 为 nil 时返回空 map（非 nil）。非 nil 指针原样保留，不做拷贝。
+//
+// 示例:
+//
+//	alice := &User{Name: "Alice"}
+//	m := CompactPtr(map[int]*User{1: alice, 2: nil})
+//	// m = map[int]*User{1: alice}
+func CompactPtr[K comparable, V any](m map[K]*V) map[K]*V {
+	r := make(map[K]*V, len(m))
+	for k, v := range m {
+		if v != nil {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// Compact 返回一个新 map，去除 m 中值为零值的条目（如 0、""、false）。
+//
+// m 为 nil 时返回空 map（非 nil）。
+//
+// 示例:
+//
+//	m := Compact(map[string]string{"host": "127.0.0.1", "user": ""})
+//	// m = map[string]string{"host": "127.0.0.1"}
+func Compact[K comparable, V comparable](m map[K]V) map[K]V {
+	var zero V
+	r := make(map[K]V, len(m))
+	for k, v := range m {
+		if v != zero {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// MapGetFilter 从 map 中获取值并通过 f 转换，f 可以拒绝已存在的值。
+//
+// 仅当 key 存在且 f 返回 true 时，ok 才为 true；例如值存在但状态不符合要求时可视为未命中。
+//
+// 参数:
+//   - m: 源 map
+//   - key: 要查找的键
+//   - f: 转换函数，返回转换后的值以及该值是否可用
+//
+// 返回值:
+//   - V: 转换后的值，key 不存在或 f 拒绝时为零值
+//   - bool: key 存在且 f 返回 true 时为 true
+//
+// 示例:
+//
+//	users := map[int]User{1: {Name: "Alice", Active: false}}
+//	name, ok := MapGetFilter(users, 1, func(u User) (string, bool) { return u.Name, u.Active })
+//	// name = "", ok = false
+func MapGetFilter[T any, K comparable, V any](m map[K]T, key K, f func(T) (V, bool)) (V, bool) {
+	var zero V
+	v, ok := m[key]
+	if !ok {
+		return zero, false
+	}
+	r, ok := f(v)
+	if !ok {
+		return zero, false
+	}
+	return r, true
+}
+
+// CountDistinct 返回切片元素派生出的不同键的数量。
+//
+// 示例:
+//
+//	orders := []Order{{UserID: 1}, {UserID: 2}, {UserID: 1}}
+//	n := CountDistinct(orders, func(o Order) int { return o.UserID })
+//	// n = 2
+func CountDistinct[T any, K comparable](list []T, key func(T) K) int {
+	seen := make(map[K]struct{}, len(list))
+	for _, v := range list {
+		seen[key(v)] = struct{}{}
+	}
+	return len(seen)
+}
+
+// SumByKey 按 key 对切片元素分组，并对每组中 value 派生的数值求和，是 MapBy 对应的聚合版本。
+//
+// 与 SumBy 对整个切片求和不同，SumByKey 返回每个键各自的和。空切片或 nil 切片返回空 map（非 nil）。
+//
+// 示例:
+//
+//	txs := []Tx{{Account: "a", Amount: 10}, {Account: "b", Amount: 5}, {Account: "a", Amount: -3}}
+//	sums := SumByKey(txs, func(t Tx) string { return t.Account }, func(t Tx) int { return t.Amount })
+//	// sums = map[string]int{"a": 7, "b": 5}
+func SumByKey[T any, K comparable, N Number](list []T, key func(T) K, value func(T) N) map[K]N {
+	m := make(map[K]N)
+	for _, v := range list {
+		m[key(v)] += value(v)
+	}
+	return m
+}
+
+// GroupReduce 按 key 对切片元素分组，并将每组元素依次折叠为一个 R。
+//
+// 每个键首次出现时调用 init 生成初始累加值，之后按切片顺序对该组的每个元素调用 reduce。
+// 空切片或 nil 切片返回空 map（非 nil）。
+//
+// 参数:
+//   - list: 源切片
+//   - key: 分组键提取函数
+//   - init: 生成每组初始累加值的函数
+//   - reduce: 折叠函数，接收当前累加值和元素，返回新的累加值
+//
+// 示例:
+//
+//	orders := []Order{{Customer: "a", Total: 10}, {Customer: "b", Total: 5}, {Customer: "a", Total: 3}}
+//	totals := GroupReduce(orders,
+//	    func(o Order) string { return o.Customer },
+//	    func() int { return 0 },
+//	    func(acc int, o Order) int { return acc + o.Total },
+//	)
+//	// totals = map[string]int{"a": 13, "b": 5}
+func GroupReduce[T any, K comparable, R any](list []T, key func(T) K, init func() R, reduce func(acc R, t T) R) map[K]R {
+	m := make(map[K]R)
+	for _, v := range list {
+		k := key(v)
+		acc, ok := m[k]
+		if !ok {
+			acc = init()
+		}
+		m[k] = reduce(acc, v)
+	}
+	return m
+}
+
+// Transform 对 m 的每个条目调用 f，同时转换键和值，返回新的 map。
+//
+// 相当于一次遍历内完成 Rekey 和值转换。若多个条目转换后的键相同，结果取决于 map 遍历顺序，
+// 只保留其中之一。m 为 nil 时返回空 map（非 nil）。
+//
+// 示例:
+//
+//	ids := map[string]int{"alice": 1, "bob": 2}
+//	names := Transform(ids, func(name string, id int) (int, string) { return id, name })
+//	// names = map[int]string{1: "alice", 2: "bob"}
+func Transform[K1 comparable, V1 any, K2 comparable, V2 any](m map[K1]V1, f func(K1, V1) (K2, V2)) map[K2]V2 {
+	r := make(map[K2]V2, len(m))
+	for k, v := range m {
+		k2, v2 := f(k, v)
+		r[k2] = v2
+	}
+	return r
+}
+
+// TransformFilter 与 Transform 相同，但 f 返回 false 时丢弃该条目。
+//
+// 示例:
+//
+//	scores := map[string]int{"alice": 90, "bob": 50}
+//	passed := TransformFilter(scores, func(name string, s int) (string, bool, bool) {
+//	    return strings.ToUpper(name), s >= 60, s >= 60
+//	})
+//	// passed = map[string]bool{"ALICE": true}
+func TransformFilter[K1 comparable, V1 any, K2 comparable, V2 any](m map[K1]V1, f func(K1, V1) (K2, V2, bool)) map[K2]V2 {
+	r := make(map[K2]V2, len(m))
+	for k, v := range m {
+		if k2, v2, ok := f(k, v); ok {
+			r[k2] = v2
+		}
+	}
+	return r
+}
@@ -1,6 +1,9 @@
 package maputil
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -148,6 +151,90 @@ func TestMapGet_NilPointerInMap(t *testing.T) {
 	}
 }
 
+// ============== MapGet2 测试 ==============
+
+func TestMapGet2_KeyExists(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	raw, v, ok := MapGet2(m, "b", func(i int) int { return i * 10 })
+	if !ok {
+		t.Error("expected ok to be true")
+	}
+	if raw != 2 {
+		t.Errorf("expected raw to be 2, got %d", raw)
+	}
+	if v != 20 {
+		t.Errorf("expected v to be 20, got %d", v)
+	}
+}
+
+func TestMapGet2_KeyNotExists(t *testing.T) {
+	m := map[string]int{"a": 1}
+	raw, v, ok := MapGet2(m, "notexist", func(i int) int { return i * 10 })
+	if ok {
+		t.Error("expected ok to be false")
+	}
+	if raw != 0 || v != 0 {
+		t.Errorf("expected zero values, got raw=%d v=%d", raw, v)
+	}
+}
+
+func TestMapGet2_ValueFuncNil(t *testing.T) {
+	m := map[string]int{"a": 1}
+	raw, v, ok := MapGet2[int, string, string](m, "a", nil)
+	if !ok {
+		t.Error("expected ok to be true when key exists")
+	}
+	if raw != 1 {
+		t.Errorf("expected raw to be populated when value func is nil, got %d", raw)
+	}
+	if v != "" {
+		t.Errorf("expected v to be zero value when value func is nil, got %q", v)
+	}
+}
+
+func TestMapGet2_NilMap(t *testing.T) {
+	var m map[string]int
+	raw, v, ok := MapGet2(m, "any", func(i int) int { return i })
+	if ok {
+		t.Error("expected ok to be false for nil map")
+	}
+	if raw != 0 || v != 0 {
+		t.Errorf("expected zero values, got raw=%d v=%d", raw, v)
+	}
+}
+
+func TestMapGet2_StructValue(t *testing.T) {
+	type User struct {
+		Name string
+		Age  int
+	}
+	m := map[int]User{1: {Name: "Alice", Age: 30}}
+	raw, name, ok := MapGet2(m, 1, func(u User) string { return u.Name })
+	if !ok {
+		t.Error("expected ok to be true")
+	}
+	if raw.Age != 30 {
+		t.Errorf("expected raw.Age to be 30, got %d", raw.Age)
+	}
+	if name != "Alice" {
+		t.Errorf("expected name to be 'Alice', got %s", name)
+	}
+}
+
+func TestMapGet2_NilPointerInMap(t *testing.T) {
+	m := map[string]*int{"nil": nil}
+	raw, isNil, ok := MapGet2(m, "nil", func(i *int) bool { return i == nil })
+	if !ok {
+		t.Error("expected ok to be true")
+	}
+	if raw != nil {
+		t.Error("expected raw to be nil pointer")
+	}
+	if !isNil {
+		t.Error("expected transformed value to be true (nil pointer)")
+	}
+}
+
 // ============== MapBy 测试 ==============
 
 func TestMapBy_Basic(t *testing.T) {
@@ -326,3 +413,1993 @@ func TestMapBy_AllSameKey(t *testing.T) {
 		t.Errorf("expected m['same'] = 5 (last element), got %d", m["same"])
 	}
 }
+
+// ============== GetOr / GetOrElse 测试 ==============
+
+func TestGetOr_KeyExists(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	if v := GetOr(m, "a", 10); v != 1 {
+		t.Errorf("expected v to be 1, got %d", v)
+	}
+}
+
+func TestGetOr_KeyNotExists(t *testing.T) {
+	m := map[string]int{"a": 1}
+	if v := GetOr(m, "b", 10); v != 10 {
+		t.Errorf("expected v to be default 10, got %d", v)
+	}
+}
+
+func TestGetOr_NilMap(t *testing.T) {
+	var m map[string]int
+	if v := GetOr(m, "a", 10); v != 10 {
+		t.Errorf("expected v to be default 10, got %d", v)
+	}
+}
+
+func TestGetOr_ZeroValueStored(t *testing.T) {
+	// key 存在但值为零值时，应返回零值而不是默认值
+	m := map[string]int{"a": 0}
+	if v := GetOr(m, "a", 10); v != 0 {
+		t.Errorf("expected v to be stored zero value, got %d", v)
+	}
+}
+
+func TestGetOrElse_KeyExists(t *testing.T) {
+	m := map[string]int{"a": 1}
+	called := false
+	v := GetOrElse(m, "a", func() int {
+		called = true
+		return 10
+	})
+	if v != 1 {
+		t.Errorf("expected v to be 1, got %d", v)
+	}
+	if called {
+		t.Error("def func should not be called when key exists")
+	}
+}
+
+func TestGetOrElse_KeyNotExists(t *testing.T) {
+	m := map[string]int{"a": 1}
+	calls := 0
+	v := GetOrElse(m, "b", func() int {
+		calls++
+		return 10
+	})
+	if v != 10 {
+		t.Errorf("expected v to be default 10, got %d", v)
+	}
+	if calls != 1 {
+		t.Errorf("expected def func to be called once, got %d", calls)
+	}
+}
+
+func TestGetOrElse_NilMap(t *testing.T) {
+	var m map[string]int
+	v := GetOrElse(m, "a", func() int { return 10 })
+	if v != 10 {
+		t.Errorf("expected v to be default 10, got %d", v)
+	}
+}
+
+// ============== Equal / EqualFunc 测试 ==============
+
+func TestEqual_SameMaps(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"b": 2, "a": 1}
+	if !Equal(a, b) {
+		t.Error("expected maps to be equal")
+	}
+}
+
+func TestEqual_DifferentLength(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"a": 1}
+	if Equal(a, b) {
+		t.Error("expected maps with different lengths to be unequal")
+	}
+}
+
+func TestEqual_DifferentValues(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"a": 1, "b": 3}
+	if Equal(a, b) {
+		t.Error("expected maps with different values to be unequal")
+	}
+}
+
+func TestEqual_DifferentKeys(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"a": 1, "c": 2}
+	if Equal(a, b) {
+		t.Error("expected maps with different keys to be unequal")
+	}
+}
+
+func TestEqual_NilAndEmpty(t *testing.T) {
+	var a map[string]int
+	b := map[string]int{}
+	if !Equal(a, b) {
+		t.Error("expected nil map and empty map to be equal")
+	}
+	if !Equal(b, a) {
+		t.Error("expected empty map and nil map to be equal")
+	}
+	if !Equal[string, int](nil, nil) {
+		t.Error("expected two nil maps to be equal")
+	}
+}
+
+func TestEqualFunc_SliceValues(t *testing.T) {
+	eq := func(x, y []int) bool {
+		if len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if x[i] != y[i] {
+				return false
+			}
+		}
+		return true
+	}
+	a := map[string][]int{"a": {1, 2}, "b": {3}}
+	b := map[string][]int{"a": {1, 2}, "b": {3}}
+	if !EqualFunc(a, b, eq) {
+		t.Error("expected maps to be equal")
+	}
+
+	b["b"] = []int{4}
+	if EqualFunc(a, b, eq) {
+		t.Error("expected maps with different values to be unequal")
+	}
+
+	if EqualFunc(a, map[string][]int{"a": {1, 2}}, eq) {
+		t.Error("expected maps with different lengths to be unequal")
+	}
+}
+
+func TestEqualFunc_NilAndEmpty(t *testing.T) {
+	called := false
+	eq := func(x, y int) bool {
+		called = true
+		return x == y
+	}
+	if !EqualFunc(nil, map[string]int{}, eq) {
+		t.Error("expected nil map and empty map to be equal")
+	}
+	if called {
+		t.Error("eq should not be called for empty maps")
+	}
+}
+
+// ============== Clone / DeepClone 测试 ==============
+
+func TestClone_Independent(t *testing.T) {
+	src := map[string]int{"a": 1, "b": 2}
+	dst := Clone(src)
+	if !Equal(src, dst) {
+		t.Errorf("expected clone to equal source, got %v", dst)
+	}
+
+	dst["a"] = 100
+	dst["c"] = 3
+	if src["a"] != 1 {
+		t.Errorf("expected src['a'] to remain 1, got %d", src["a"])
+	}
+	if _, ok := src["c"]; ok {
+		t.Error("expected src not to contain key 'c'")
+	}
+
+	delete(src, "b")
+	if _, ok := dst["b"]; !ok {
+		t.Error("expected dst to still contain key 'b'")
+	}
+}
+
+func TestClone_Nil(t *testing.T) {
+	var m map[string]int
+	if r := Clone(m); r != nil {
+		t.Errorf("expected nil, got %v", r)
+	}
+}
+
+func TestClone_Empty(t *testing.T) {
+	r := Clone(map[string]int{})
+	if r == nil {
+		t.Error("expected non-nil map")
+	}
+	if len(r) != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+}
+
+func TestDeepClone_SliceValues(t *testing.T) {
+	src := map[string][]int{"a": {1, 2}, "b": {3}}
+	dst := DeepClone(src, func(s []int) []int { return append([]int(nil), s...) })
+
+	dst["a"][0] = 100
+	if src["a"][0] != 1 {
+		t.Errorf("expected src['a'][0] to remain 1, got %d", src["a"][0])
+	}
+
+	src["b"][0] = 300
+	if dst["b"][0] != 3 {
+		t.Errorf("expected dst['b'][0] to remain 3, got %d", dst["b"][0])
+	}
+}
+
+func TestDeepClone_Nil(t *testing.T) {
+	var m map[string][]int
+	called := false
+	r := DeepClone(m, func(s []int) []int {
+		called = true
+		return s
+	})
+	if r != nil {
+		t.Errorf("expected nil, got %v", r)
+	}
+	if called {
+		t.Error("copyVal should not be called for nil map")
+	}
+}
+
+// ============== Pick / Omit 测试 ==============
+
+func TestPick_Basic(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	r := Pick(m, "a", "c")
+	if !Equal(r, map[string]int{"a": 1, "c": 3}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+	if len(m) != 3 {
+		t.Errorf("expected source to be unchanged, got %v", m)
+	}
+}
+
+func TestPick_MissingKeysSkipped(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	r := Pick(m, "a", "x", "y")
+	if !Equal(r, map[string]int{"a": 1}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+	if _, ok := r["x"]; ok {
+		t.Error("expected missing key 'x' to be skipped")
+	}
+}
+
+func TestPick_NilMap(t *testing.T) {
+	var m map[string]int
+	r := Pick(m, "a")
+	if r == nil {
+		t.Error("expected non-nil map")
+	}
+	if len(r) != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+}
+
+func TestOmit_Basic(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	r := Omit(m, "b", "x")
+	if !Equal(r, map[string]int{"a": 1, "c": 3}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+	if !Equal(m, map[string]int{"a": 1, "b": 2, "c": 3}) {
+		t.Errorf("expected source to be unchanged, got %v", m)
+	}
+}
+
+func TestOmit_AllKeys(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	r := Omit(m, "a", "b")
+	if r == nil {
+		t.Error("expected non-nil map")
+	}
+	if len(r) != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+	if len(m) != 2 {
+		t.Errorf("expected source to be unchanged, got %v", m)
+	}
+}
+
+func TestOmit_NilMap(t *testing.T) {
+	var m map[string]int
+	r := Omit(m, "a")
+	if r == nil {
+		t.Error("expected non-nil map")
+	}
+	if len(r) != 0 {
+		t.Errorf("expected empty map, got %v", r)
+	}
+}
+
+// ============== Reduce / ReduceSorted 测试 ==============
+
+func TestReduce_Sum(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	sum := Reduce(m, 0, func(acc int, _ string, v int) int { return acc + v })
+	if sum != 6 {
+		t.Errorf("expected sum 6, got %d", sum)
+	}
+}
+
+func TestReduce_Count(t *testing.T) {
+	m := map[int]string{1: "x", 2: "y", 3: "z", 4: "w"}
+	n := Reduce(m, 0, func(acc int, _ int, _ string) int { return acc + 1 })
+	if n != 4 {
+		t.Errorf("expected count 4, got %d", n)
+	}
+}
+
+func TestReduce_NilMap(t *testing.T) {
+	var m map[string]int
+	r := Reduce(m, 42, func(acc int, _ string, v int) int { return acc + v })
+	if r != 42 {
+		t.Errorf("expected init value 42, got %d", r)
+	}
+}
+
+func TestReduceSorted_ConcatKeys(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2, "d": 4}
+	s := ReduceSorted(m, "", func(acc string, k string, _ int) string { return acc + k })
+	if s != "abcd" {
+		t.Errorf("expected 'abcd', got %s", s)
+	}
+}
+
+func TestReduceSorted_EmptyMap(t *testing.T) {
+	s := ReduceSorted(map[int]string{}, "init", func(acc string, _ int, v string) string { return acc + v })
+	if s != "init" {
+		t.Errorf("expected 'init', got %s", s)
+	}
+}
+
+// ============== Any / All / None 测试 ==============
+
+func TestAny(t *testing.T) {
+	calls := 0
+	isNeg := func(_ string, v int) bool {
+		calls++
+		return v < 0
+	}
+
+	if Any(map[string]int{}, isNeg) {
+		t.Error("expected Any to be false for empty map")
+	}
+	if Any(map[string]int{"a": 1, "b": 2}, isNeg) {
+		t.Error("expected Any to be false when none match")
+	}
+	if !Any(map[string]int{"a": -1, "b": -2}, isNeg) {
+		t.Error("expected Any to be true when all match")
+	}
+	if !Any(map[string]int{"a": 1, "b": -2, "c": 3}, isNeg) {
+		t.Error("expected Any to be true for mixed map")
+	}
+
+	// 全部满足时应在第一次命中后短路
+	calls = 0
+	Any(map[string]int{"a": -1, "b": -2, "c": -3}, isNeg)
+	if calls != 1 {
+		t.Errorf("expected Any to short-circuit after 1 call, got %d", calls)
+	}
+}
+
+func TestAll(t *testing.T) {
+	calls := 0
+	isPos := func(_ string, v int) bool {
+		calls++
+		return v > 0
+	}
+
+	if !All(map[string]int{}, isPos) {
+		t.Error("expected All to be true for empty map")
+	}
+	if !All(map[string]int{"a": 1, "b": 2}, isPos) {
+		t.Error("expected All to be true when all match")
+	}
+	if All(map[string]int{"a": -1, "b": -2}, isPos) {
+		t.Error("expected All to be false when none match")
+	}
+	if All(map[string]int{"a": 1, "b": -2, "c": 3}, isPos) {
+		t.Error("expected All to be false for mixed map")
+	}
+
+	// 全部不满足时应在第一次失败后短路
+	calls = 0
+	All(map[string]int{"a": -1, "b": -2, "c": -3}, isPos)
+	if calls != 1 {
+		t.Errorf("expected All to short-circuit after 1 call, got %d", calls)
+	}
+}
+
+func TestNone(t *testing.T) {
+	calls := 0
+	isNeg := func(_ string, v int) bool {
+		calls++
+		return v < 0
+	}
+
+	if !None(map[string]int{}, isNeg) {
+		t.Error("expected None to be true for empty map")
+	}
+	if !None(map[string]int{"a": 1, "b": 2}, isNeg) {
+		t.Error("expected None to be true when none match")
+	}
+	if None(map[string]int{"a": -1, "b": -2}, isNeg) {
+		t.Error("expected None to be false when all match")
+	}
+	if None(map[string]int{"a": 1, "b": -2, "c": 3}, isNeg) {
+		t.Error("expected None to be false for mixed map")
+	}
+
+	// 全部满足时应在第一次命中后短路
+	calls = 0
+	None(map[string]int{"a": -1, "b": -2, "c": -3}, isNeg)
+	if calls != 1 {
+		t.Errorf("expected None to short-circuit after 1 call, got %d", calls)
+	}
+}
+
+func TestAnyAllNone_NilMap(t *testing.T) {
+	var m map[string]int
+	pred := func(_ string, _ int) bool { return true }
+	if Any(m, pred) {
+		t.Error("expected Any to be false for nil map")
+	}
+	if !All(m, pred) {
+		t.Error("expected All to be true for nil map")
+	}
+	if !None(m, pred) {
+		t.Error("expected None to be true for nil map")
+	}
+}
+
+// ============== Find / FindSorted 测试 ==============
+
+func TestFind_Match(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	k, v, ok := Find(m, func(_ string, v int) bool { return v == 2 })
+	if !ok {
+		t.Error("expected ok to be true")
+	}
+	if k != "b" || v != 2 {
+		t.Errorf("expected (b, 2), got (%s, %d)", k, v)
+	}
+}
+
+func TestFind_NoMatch(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	k, v, ok := Find(m, func(_ string, v int) bool { return v > 10 })
+	if ok {
+		t.Error("expected ok to be false")
+	}
+	if k != "" || v != 0 {
+		t.Errorf("expected zero values, got (%q, %d)", k, v)
+	}
+}
+
+func TestFind_NilMap(t *testing.T) {
+	var m map[string]int
+	k, v, ok := Find(m, func(_ string, _ int) bool { return true })
+	if ok {
+		t.Error("expected ok to be false for nil map")
+	}
+	if k != "" || v != 0 {
+		t.Errorf("expected zero values, got (%q, %d)", k, v)
+	}
+}
+
+func TestFindSorted_Deterministic(t *testing.T) {
+	m := map[string]int{"d": 4, "b": 2, "c": 3, "a": 1}
+	for i := 0; i < 10; i++ {
+		k, v, ok := FindSorted(m, func(_ string, v int) bool { return v > 1 })
+		if !ok {
+			t.Fatal("expected ok to be true")
+		}
+		if k != "b" || v != 2 {
+			t.Fatalf("expected (b, 2), got (%s, %d)", k, v)
+		}
+	}
+}
+
+func TestFindSorted_NoMatch(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b"}
+	k, v, ok := FindSorted(m, func(_ int, v string) bool { return v == "z" })
+	if ok {
+		t.Error("expected ok to be false")
+	}
+	if k != 0 || v != "" {
+		t.Errorf("expected zero values, got (%d, %q)", k, v)
+	}
+}
+
+func TestFindSorted_NilMap(t *testing.T) {
+	var m map[int]string
+	_, _, ok := FindSorted(m, func(_ int, _ string) bool { return true })
+	if ok {
+		t.Error("expected ok to be false for nil map")
+	}
+}
+
+// ============== Count / CountBy 测试 ==============
+
+func TestCount(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	if n := Count(m, func(_ string, _ int) bool { return true }); n != 3 {
+		t.Errorf("expected 3 for all-match, got %d", n)
+	}
+	if n := Count(m, func(_ string, _ int) bool { return false }); n != 0 {
+		t.Errorf("expected 0 for none-match, got %d", n)
+	}
+	if n := Count(m, func(_ string, v int) bool { return v%2 == 1 }); n != 2 {
+		t.Errorf("expected 2 for mixed, got %d", n)
+	}
+}
+
+func TestCount_NilMap(t *testing.T) {
+	var m map[string]int
+	if n := Count(m, func(_ string, _ int) bool { return true }); n != 0 {
+		t.Errorf("expected 0 for nil map, got %d", n)
+	}
+}
+
+func TestCountBy_DuplicateKeys(t *testing.T) {
+	list := []string{"apple", "avocado", "banana", "apricot", "cherry"}
+	m := CountBy(list, func(s string) byte { return s[0] })
+	if len(m) != 3 {
+		t.Errorf("expected 3 distinct keys, got %d", len(m))
+	}
+	if m['a'] != 3 {
+		t.Errorf("expected m['a'] = 3, got %d", m['a'])
+	}
+	if m['b'] != 1 {
+		t.Errorf("expected m['b'] = 1, got %d", m['b'])
+	}
+	if m['c'] != 1 {
+		t.Errorf("expected m['c'] = 1, got %d", m['c'])
+	}
+}
+
+func TestCountBy_EmptyAndNil(t *testing.T) {
+	m := CountBy([]int{}, func(i int) int { return i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+
+	var list []int
+	m = CountBy(list, func(i int) int { return i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+}
+
+// ============== MapToSlice / MapToSliceSorted 测试 ==============
+
+func TestMapToSlice_Structs(t *testing.T) {
+	type User struct {
+		ID   int
+		Name string
+	}
+	m := map[int]string{1: "Alice", 2: "Bob", 3: "Charlie"}
+	users := MapToSlice(m, func(id int, name string) User { return User{ID: id, Name: name} })
+
+	if len(users) != len(m) {
+		t.Fatalf("expected length %d, got %d", len(m), len(users))
+	}
+	for _, u := range users {
+		if m[u.ID] != u.Name {
+			t.Errorf("unexpected user %+v", u)
+		}
+	}
+}
+
+func TestMapToSlice_NilMap(t *testing.T) {
+	var m map[int]string
+	r := MapToSlice(m, func(_ int, v string) string { return v })
+	if r == nil {
+		t.Error("expected non-nil slice")
+	}
+	if len(r) != 0 {
+		t.Errorf("expected empty slice, got %v", r)
+	}
+}
+
+func TestMapToSliceSorted_KeyOrder(t *testing.T) {
+	m := map[int]string{3: "c", 1: "a", 2: "b", 4: "d"}
+	r := MapToSliceSorted(m, func(k int, v string) string { return fmt.Sprintf("%d%s", k, v) })
+	expected := []string{"1a", "2b", "3c", "4d"}
+	if len(r) != len(expected) {
+		t.Fatalf("expected length %d, got %d", len(expected), len(r))
+	}
+	for i := range expected {
+		if r[i] != expected[i] {
+			t.Errorf("expected r[%d] = %s, got %s", i, expected[i], r[i])
+		}
+	}
+}
+
+// ============== Intersect / Union / Subtract 测试 ==============
+
+func TestIntersect(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	if r := Intersect(a, map[string]int{"x": 1, "y": 2}); len(r) != 0 {
+		t.Errorf("expected empty result for disjoint maps, got %v", r)
+	}
+	if r := Intersect(a, map[string]int{"b": 20, "c": 30, "d": 40}); !Equal(r, map[string]int{"b": 2, "c": 3}) {
+		t.Errorf("unexpected result for overlapping maps: %v", r)
+	}
+	if r := Intersect(a, map[string]int{"a": 10, "b": 20, "c": 30}); !Equal(r, a) {
+		t.Errorf("expected values from a for identical key sets, got %v", r)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+
+	if r := Union(a, map[string]int{"x": 10}); !Equal(r, map[string]int{"a": 1, "b": 2, "x": 10}) {
+		t.Errorf("unexpected result for disjoint maps: %v", r)
+	}
+	if r := Union(a, map[string]int{"b": 20, "c": 30}); !Equal(r, map[string]int{"a": 1, "b": 20, "c": 30}) {
+		t.Errorf("expected b to win conflicts, got %v", r)
+	}
+	if r := Union(a, map[string]int{"a": 10, "b": 20}); !Equal(r, map[string]int{"a": 10, "b": 20}) {
+		t.Errorf("unexpected result for identical key sets: %v", r)
+	}
+	if !Equal(a, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("expected source to be unchanged, got %v", a)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	if r := Subtract(a, map[string]int{"x": 1}); !Equal(r, a) {
+		t.Errorf("expected a unchanged for disjoint maps, got %v", r)
+	}
+	if r := Subtract(a, map[string]int{"b": 20, "d": 40}); !Equal(r, map[string]int{"a": 1, "c": 3}) {
+		t.Errorf("unexpected result for overlapping maps: %v", r)
+	}
+	if r := Subtract(a, map[string]int{"a": 0, "b": 0, "c": 0}); len(r) != 0 {
+		t.Errorf("expected empty result for identical key sets, got %v", r)
+	}
+}
+
+func TestSetOperations_NilInputs(t *testing.T) {
+	a := map[string]int{"a": 1}
+
+	if r := Intersect(nil, a); r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+	if r := Intersect(a, nil); r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+	if r := Union(nil, a); !Equal(r, a) {
+		t.Errorf("expected %v, got %v", a, r)
+	}
+	if r := Union[string, int](nil, nil); r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+	if r := Subtract(a, nil); !Equal(r, a) {
+		t.Errorf("expected %v, got %v", a, r)
+	}
+	if r := Subtract(nil, a); r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+}
+
+// ============== MapByWith 测试 ==============
+
+type mapByWithItem struct {
+	ID     int
+	Amount int
+}
+
+var mapByWithList = []mapByWithItem{
+	{ID: 1, Amount: 10},
+	{ID: 2, Amount: 5},
+	{ID: 1, Amount: 20},
+	{ID: 1, Amount: 30},
+}
+
+func TestMapByWith_KeepFirst(t *testing.T) {
+	m := MapByWith(mapByWithList,
+		func(i mapByWithItem) int { return i.ID },
+		func(i mapByWithItem) int { return i.Amount },
+		func(existing, _ int) int { return existing },
+	)
+	if !Equal(m, map[int]int{1: 10, 2: 5}) {
+		t.Errorf("expected keep-first result, got %v", m)
+	}
+}
+
+func TestMapByWith_Sum(t *testing.T) {
+	m := MapByWith(mapByWithList,
+		func(i mapByWithItem) int { return i.ID },
+		func(i mapByWithItem) int { return i.Amount },
+		func(existing, incoming int) int { return existing + incoming },
+	)
+	if !Equal(m, map[int]int{1: 60, 2: 5}) {
+		t.Errorf("expected summed result, got %v", m)
+	}
+}
+
+func TestMapByWith_NilResolve_LastWins(t *testing.T) {
+	m := MapByWith(mapByWithList,
+		func(i mapByWithItem) int { return i.ID },
+		func(i mapByWithItem) int { return i.Amount },
+		nil,
+	)
+	if !Equal(m, map[int]int{1: 30, 2: 5}) {
+		t.Errorf("expected last-wins result, got %v", m)
+	}
+}
+
+func TestMapByWith_ResolveNotCalledWithoutConflict(t *testing.T) {
+	called := false
+	m := MapByWith([]int{1, 2, 3},
+		func(i int) int { return i },
+		func(i int) int { return i },
+		func(existing, _ int) int {
+			called = true
+			return existing
+		},
+	)
+	if len(m) != 3 {
+		t.Errorf("expected map length 3, got %d", len(m))
+	}
+	if called {
+		t.Error("resolve should not be called when keys are unique")
+	}
+}
+
+// ============== MinKey / MaxKey / MinValueBy / MaxValueBy 测试 ==============
+
+func TestMinMaxKey(t *testing.T) {
+	m := map[int]string{5: "e", 1: "a", 9: "i", 3: "c"}
+	if k, ok := MinKey(m); !ok || k != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", k, ok)
+	}
+	if k, ok := MaxKey(m); !ok || k != 9 {
+		t.Errorf("expected (9, true), got (%d, %v)", k, ok)
+	}
+}
+
+func TestMinMaxKey_Negative(t *testing.T) {
+	m := map[int]bool{-5: true, -1: true}
+	if k, _ := MinKey(m); k != -5 {
+		t.Errorf("expected -5, got %d", k)
+	}
+	if k, _ := MaxKey(m); k != -1 {
+		t.Errorf("expected -1, got %d", k)
+	}
+}
+
+func TestMinMaxKey_Empty(t *testing.T) {
+	var m map[string]int
+	if k, ok := MinKey(m); ok || k != "" {
+		t.Errorf("expected ('', false), got (%q, %v)", k, ok)
+	}
+	if k, ok := MaxKey(map[string]int{}); ok || k != "" {
+		t.Errorf("expected ('', false), got (%q, %v)", k, ok)
+	}
+}
+
+func TestMinMaxValueBy(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	scores := map[string]int{"alice": 90, "bob": 75, "carol": 82}
+
+	k, v, ok := MinValueBy(scores, less)
+	if !ok || k != "bob" || v != 75 {
+		t.Errorf("expected (bob, 75, true), got (%s, %d, %v)", k, v, ok)
+	}
+	k, v, ok = MaxValueBy(scores, less)
+	if !ok || k != "alice" || v != 90 {
+		t.Errorf("expected (alice, 90, true), got (%s, %d, %v)", k, v, ok)
+	}
+}
+
+func TestMinMaxValueBy_Ties(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	scores := map[string]int{"alice": 90, "bob": 90, "carol": 60, "dave": 60}
+
+	k, v, ok := MaxValueBy(scores, less)
+	if !ok || v != 90 || (k != "alice" && k != "bob") {
+		t.Errorf("expected one of alice/bob with 90, got (%s, %d, %v)", k, v, ok)
+	}
+	k, v, ok = MinValueBy(scores, less)
+	if !ok || v != 60 || (k != "carol" && k != "dave") {
+		t.Errorf("expected one of carol/dave with 60, got (%s, %d, %v)", k, v, ok)
+	}
+}
+
+func TestMinMaxValueBy_Empty(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	if k, v, ok := MinValueBy(map[string]int{}, less); ok || k != "" || v != 0 {
+		t.Errorf("expected zero values and false, got (%q, %d, %v)", k, v, ok)
+	}
+	if k, v, ok := MaxValueBy[string, int](nil, less); ok || k != "" || v != 0 {
+		t.Errorf("expected zero values and false, got (%q, %d, %v)", k, v, ok)
+	}
+}
+
+// ============== Sum / SumBy 测试 ==============
+
+func TestSum_Int(t *testing.T) {
+	if s := Sum(map[string]int{"a": 1, "b": 2, "c": 3}); s != 6 {
+		t.Errorf("expected 6, got %d", s)
+	}
+}
+
+func TestSum_Float(t *testing.T) {
+	if s := Sum(map[string]float64{"a": 1.5, "b": 2.25}); s != 3.75 {
+		t.Errorf("expected 3.75, got %f", s)
+	}
+}
+
+func TestSum_CustomType(t *testing.T) {
+	type Cents int64
+	if s := Sum(map[string]Cents{"a": 100, "b": 250}); s != 350 {
+		t.Errorf("expected 350, got %d", s)
+	}
+}
+
+func TestSum_Empty(t *testing.T) {
+	if s := Sum(map[string]int{}); s != 0 {
+		t.Errorf("expected 0, got %d", s)
+	}
+	if s := Sum[string, float64](nil); s != 0 {
+		t.Errorf("expected 0, got %f", s)
+	}
+}
+
+func TestSumBy_Structs(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price float64
+		Qty   int
+	}
+	items := []Item{
+		{Name: "a", Price: 1.5, Qty: 2},
+		{Name: "b", Price: 2.5, Qty: 3},
+	}
+	if total := SumBy(items, func(i Item) float64 { return i.Price * float64(i.Qty) }); total != 10.5 {
+		t.Errorf("expected 10.5, got %f", total)
+	}
+	if qty := SumBy(items, func(i Item) int { return i.Qty }); qty != 5 {
+		t.Errorf("expected 5, got %d", qty)
+	}
+}
+
+func TestSumBy_Empty(t *testing.T) {
+	var list []int
+	if s := SumBy(list, func(i int) int { return i }); s != 0 {
+		t.Errorf("expected 0, got %d", s)
+	}
+}
+
+// ============== GetMany / GetManyAs 测试 ==============
+
+func TestGetMany_AllPresent(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	found, missing := GetMany(m, "a", "c")
+	if !Equal(found, map[string]int{"a": 1, "c": 3}) {
+		t.Errorf("unexpected found: %v", found)
+	}
+	if len(missing) != 0 {
+		t.Errorf("expected no missing keys, got %v", missing)
+	}
+}
+
+func TestGetMany_AllMissing(t *testing.T) {
+	m := map[string]int{"a": 1}
+	found, missing := GetMany(m, "x", "y")
+	if found == nil || len(found) != 0 {
+		t.Errorf("expected non-nil empty found map, got %v", found)
+	}
+	if len(missing) != 2 || missing[0] != "x" || missing[1] != "y" {
+		t.Errorf("expected missing [x y], got %v", missing)
+	}
+}
+
+func TestGetMany_Partial(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	found, missing := GetMany(m, "x", "a", "y", "b")
+	if !Equal(found, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("unexpected found: %v", found)
+	}
+	if len(missing) != 2 || missing[0] != "x" || missing[1] != "y" {
+		t.Errorf("expected missing [x y] in input order, got %v", missing)
+	}
+}
+
+func TestGetMany_NilMap(t *testing.T) {
+	var m map[string]int
+	found, missing := GetMany(m, "a", "b")
+	if len(found) != 0 {
+		t.Errorf("expected empty found map, got %v", found)
+	}
+	if len(missing) != 2 {
+		t.Errorf("expected all keys missing, got %v", missing)
+	}
+}
+
+func TestGetManyAs(t *testing.T) {
+	type User struct {
+		Name string
+	}
+	users := map[int]User{1: {Name: "Alice"}, 2: {Name: "Bob"}}
+
+	names := GetManyAs(users, []int{1, 2}, func(u User) string { return u.Name })
+	if !Equal(names, map[int]string{1: "Alice", 2: "Bob"}) {
+		t.Errorf("unexpected result for all-present: %v", names)
+	}
+
+	names = GetManyAs(users, []int{2, 3}, func(u User) string { return u.Name })
+	if !Equal(names, map[int]string{2: "Bob"}) {
+		t.Errorf("unexpected result for partial: %v", names)
+	}
+
+	names = GetManyAs(nil, []int{1}, func(u User) string { return u.Name })
+	if names == nil || len(names) != 0 {
+		t.Errorf("expected non-nil empty map for nil input, got %v", names)
+	}
+}
+
+// ============== Partition 测试 ==============
+
+func TestPartition_Mixed(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	odd, even := Partition(m, func(_ string, v int) bool { return v%2 == 1 })
+	if !Equal(odd, map[string]int{"a": 1, "c": 3}) {
+		t.Errorf("unexpected matched: %v", odd)
+	}
+	if !Equal(even, map[string]int{"b": 2}) {
+		t.Errorf("unexpected rest: %v", even)
+	}
+	if !Equal(m, map[string]int{"a": 1, "b": 2, "c": 3}) {
+		t.Errorf("expected source to be unchanged, got %v", m)
+	}
+}
+
+func TestPartition_AllMatch(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	matched, rest := Partition(m, func(_ string, _ int) bool { return true })
+	if !Equal(matched, m) {
+		t.Errorf("expected all entries matched, got %v", matched)
+	}
+	if rest == nil || len(rest) != 0 {
+		t.Errorf("expected non-nil empty rest, got %v", rest)
+	}
+}
+
+func TestPartition_NoneMatch(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	matched, rest := Partition(m, func(_ string, _ int) bool { return false })
+	if matched == nil || len(matched) != 0 {
+		t.Errorf("expected non-nil empty matched, got %v", matched)
+	}
+	if !Equal(rest, m) {
+		t.Errorf("expected all entries in rest, got %v", rest)
+	}
+}
+
+func TestPartition_NilMap(t *testing.T) {
+	matched, rest := Partition[string, int](nil, func(_ string, _ int) bool { return true })
+	if matched == nil || rest == nil {
+		t.Error("expected both results to be non-nil")
+	}
+	if len(matched) != 0 || len(rest) != 0 {
+		t.Errorf("expected empty results, got %v and %v", matched, rest)
+	}
+}
+
+// ============== SameKeys 测试 ==============
+
+func TestSameKeys_DifferentValueTypes(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]bool{"b": false, "a": true}
+	if !SameKeys(a, b) {
+		t.Error("expected identical key sets to be equal")
+	}
+}
+
+func TestSameKeys_DifferentValues(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"a": 100, "b": 200}
+	if !SameKeys(a, b) {
+		t.Error("expected values to be ignored")
+	}
+}
+
+func TestSameKeys_DifferentKeySets(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2}
+	if SameKeys(a, map[string]int{"a": 1, "c": 2}) {
+		t.Error("expected different key sets to be unequal")
+	}
+	if SameKeys(a, map[string]int{"a": 1}) {
+		t.Error("expected different lengths to be unequal")
+	}
+}
+
+func TestSameKeys_NilAndEmpty(t *testing.T) {
+	var a map[string]int
+	b := map[string]string{}
+	if !SameKeys(a, b) {
+		t.Error("expected nil map and empty map to have same keys")
+	}
+	if SameKeys(a, map[string]string{"a": ""}) {
+		t.Error("expected nil map and non-empty map to differ")
+	}
+}
+
+// ============== MapByUnique 测试 ==============
+
+func TestMapByUnique_Unique(t *testing.T) {
+	list := []string{"apple", "banana", "cherry"}
+	m, err := MapByUnique(list, func(s string) string { return s[:1] }, func(s string) int { return len(s) })
+	if err != nil {
+		t.Fatalf("MapByUnique should not return error: %v", err)
+	}
+	if !Equal(m, map[string]int{"a": 5, "b": 6, "c": 6}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+}
+
+func TestMapByUnique_DuplicateKey(t *testing.T) {
+	type User struct {
+		ID   int
+		Name string
+	}
+	users := []User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}, {ID: 1, Name: "Carol"}}
+	m, err := MapByUnique(users, func(u User) int { return u.ID }, func(u User) string { return u.Name })
+	if err == nil {
+		t.Fatal("MapByUnique should return error for duplicate keys")
+	}
+	if !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey, got %v", err)
+	}
+	if m != nil {
+		t.Errorf("expected nil map on error, got %v", m)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "key 1 ") || !strings.Contains(msg, "index 0 and 2") {
+		t.Errorf("expected error to report key 1 at index 0 and 2, got %q", msg)
+	}
+}
+
+func TestMapByUnique_Empty(t *testing.T) {
+	m, err := MapByUnique([]int(nil), func(i int) int { return i }, func(i int) int { return i })
+	if err != nil {
+		t.Fatalf("MapByUnique should not return error: %v", err)
+	}
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+}
+
+// ============== Chunk 测试 ==============
+
+func TestChunk_CoversAllEntries(t *testing.T) {
+	m := make(map[int]int)
+	for i := 0; i < 10; i++ {
+		m[i] = i * 10
+	}
+	chunks := Chunk(m, 3)
+	if len(chunks) != 4 {
+		t.Fatalf("expected 4 chunks, got %d", len(chunks))
+	}
+
+	seen := make(map[int]int)
+	for i, c := range chunks {
+		if len(c) > 3 {
+			t.Errorf("chunk %d exceeds size: %d", i, len(c))
+		}
+		for k, v := range c {
+			if _, dup := seen[k]; dup {
+				t.Errorf("key %d appears in more than one chunk", k)
+			}
+			seen[k] = v
+		}
+	}
+	if !Equal(seen, m) {
+		t.Errorf("expected chunks to cover all entries exactly once, got %v", seen)
+	}
+}
+
+func TestChunk_ExactMultiple(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	chunks := Chunk(m, 2)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	for i, c := range chunks {
+		if len(c) != 2 {
+			t.Errorf("expected chunk %d to have 2 entries, got %d", i, len(c))
+		}
+	}
+}
+
+func TestChunk_NonPositiveSize(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	for _, size := range []int{0, -1} {
+		chunks := Chunk(m, size)
+		if len(chunks) != 1 {
+			t.Fatalf("expected 1 chunk for size %d, got %d", size, len(chunks))
+		}
+		if !Equal(chunks[0], m) {
+			t.Errorf("expected whole map for size %d, got %v", size, chunks[0])
+		}
+		chunks[0]["c"] = 3
+		if _, ok := m["c"]; ok {
+			t.Error("expected chunk to be a copy of the source")
+		}
+	}
+}
+
+func TestChunk_Empty(t *testing.T) {
+	if chunks := Chunk(map[string]int{}, 2); chunks == nil || len(chunks) != 0 {
+		t.Errorf("expected non-nil empty slice, got %v", chunks)
+	}
+	if chunks := Chunk[string, int](nil, 2); chunks == nil || len(chunks) != 0 {
+		t.Errorf("expected non-nil empty slice, got %v", chunks)
+	}
+}
+
+// ============== Rekey / RekeyUnique 测试 ==============
+
+func TestRekey_CollisionFree(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	r := Rekey(m, func(k string) string { return "p_" + k })
+	if !Equal(r, map[string]int{"p_a": 1, "p_b": 2}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+}
+
+func TestRekey_ChangeKeyType(t *testing.T) {
+	m := map[string]bool{"apple": true, "kiwi": false}
+	r := Rekey(m, func(k string) int { return len(k) })
+	if !Equal(r, map[int]bool{5: true, 4: false}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+}
+
+func TestRekey_Collision_LastWins(t *testing.T) {
+	m := map[string]int{"a": 1, "A": 2, "b": 3}
+	r := Rekey(m, strings.ToLower)
+	if len(r) != 2 {
+		t.Fatalf("expected 2 keys after collision, got %v", r)
+	}
+	if v := r["a"]; v != 1 && v != 2 {
+		t.Errorf("expected r['a'] to be one of the colliding values, got %d", v)
+	}
+	if r["b"] != 3 {
+		t.Errorf("expected r['b'] = 3, got %d", r["b"])
+	}
+}
+
+func TestRekey_Nil(t *testing.T) {
+	r := Rekey[string, string, int](nil, strings.ToUpper)
+	if r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+}
+
+func TestRekeyUnique_CollisionFree(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	r, err := RekeyUnique(m, strings.ToUpper)
+	if err != nil {
+		t.Fatalf("RekeyUnique should not return error: %v", err)
+	}
+	if !Equal(r, map[string]int{"A": 1, "B": 2}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+}
+
+func TestRekeyUnique_Collision(t *testing.T) {
+	m := map[string]int{"a": 1, "A": 2}
+	r, err := RekeyUnique(m, strings.ToLower)
+	if !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey, got %v", err)
+	}
+	if r != nil {
+		t.Errorf("expected nil map on error, got %v", r)
+	}
+}
+
+func TestRekeyUnique_Nil(t *testing.T) {
+	r, err := RekeyUnique[string, string, int](nil, strings.ToUpper)
+	if err != nil {
+		t.Fatalf("RekeyUnique should not return error: %v", err)
+	}
+	if r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+}
+
+// ============== MapByFiltered 测试 ==============
+
+func TestMapByFiltered_SkipSome(t *testing.T) {
+	list := []int{1, 2, 3, 4, 5}
+	keyCalls := 0
+	m := MapByFiltered(list,
+		func(i int) bool { return i%2 == 0 },
+		func(i int) int {
+			keyCalls++
+			return i
+		},
+		func(i int) int { return i * 10 },
+	)
+	if !Equal(m, map[int]int{2: 20, 4: 40}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+	if keyCalls != 2 {
+		t.Errorf("expected key func to be called only for included elements, got %d calls", keyCalls)
+	}
+}
+
+func TestMapByFiltered_SkipAll(t *testing.T) {
+	m := MapByFiltered([]int{1, 2, 3},
+		func(int) bool { return false },
+		func(i int) int { return i },
+		func(i int) int { return i },
+	)
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+}
+
+func TestMapByFiltered_NilInclude(t *testing.T) {
+	list := []string{"apple", "banana", "apricot"}
+	m := MapByFiltered(list, nil,
+		func(s string) string { return s[:1] },
+		func(s string) string { return s },
+	)
+	if !Equal(m, MapBy(list, func(s string) string { return s[:1] }, func(s string) string { return s })) {
+		t.Errorf("expected nil include to behave like MapBy, got %v", m)
+	}
+}
+
+// ============== Update / Delete 测试 ==============
+
+func TestUpdate_IncrementExisting(t *testing.T) {
+	m := map[string]int{"a": 1}
+	Update(m, "a", func(old int, existed bool) int {
+		if !existed {
+			t.Error("expected existed to be true")
+		}
+		return old + 1
+	})
+	if m["a"] != 2 {
+		t.Errorf("expected m['a'] = 2, got %d", m["a"])
+	}
+}
+
+func TestUpdate_InitMissing(t *testing.T) {
+	m := map[string]int{}
+	Update(m, "a", func(old int, existed bool) int {
+		if existed {
+			t.Error("expected existed to be false")
+		}
+		if old != 0 {
+			t.Errorf("expected old to be zero value, got %d", old)
+		}
+		return 100
+	})
+	if m["a"] != 100 {
+		t.Errorf("expected m['a'] = 100, got %d", m["a"])
+	}
+}
+
+func TestUpdate_Counter(t *testing.T) {
+	m := map[string]int{}
+	for _, w := range []string{"a", "b", "a", "a"} {
+		Update(m, w, func(old int, _ bool) int { return old + 1 })
+	}
+	if !Equal(m, map[string]int{"a": 3, "b": 1}) {
+		t.Errorf("unexpected counts: %v", m)
+	}
+}
+
+func TestUpdate_NilMapPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Update should panic for nil map")
+		}
+	}()
+	var m map[string]int
+	Update(m, "a", func(int, bool) int { return 1 })
+}
+
+func TestDelete_MultipleKeys(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	Delete(m, "a", "c", "x")
+	if !Equal(m, map[string]int{"b": 2}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+}
+
+func TestDelete_NilMap(t *testing.T) {
+	var m map[string]int
+	Delete(m, "a")
+}
+
+// ============== MapByReport 测试 ==============
+
+func TestMapByReport_NoDuplicates(t *testing.T) {
+	list := []string{"apple", "banana", "cherry"}
+	m, dropped := MapByReport(list, func(s string) string { return s[:1] }, func(s string) int { return len(s) })
+	if !Equal(m, map[string]int{"a": 5, "b": 6, "c": 6}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+	if dropped != nil {
+		t.Errorf("expected nil dropped, got %v", dropped)
+	}
+}
+
+func TestMapByReport_MultipleDuplicates(t *testing.T) {
+	type User struct {
+		ID   int
+		Name string
+	}
+	users := []User{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 2, Name: "Bill"},
+		{ID: 1, Name: "Carol"},
+		{ID: 1, Name: "Dave"},
+		{ID: 3, Name: "Eve"},
+	}
+	m, dropped := MapByReport(users, func(u User) int { return u.ID }, func(u User) string { return u.Name })
+
+	if !Equal(m, MapBy(users, func(u User) int { return u.ID }, func(u User) string { return u.Name })) {
+		t.Errorf("expected same result as MapBy, got %v", m)
+	}
+	if !Equal(m, map[int]string{1: "Dave", 2: "Bill", 3: "Eve"}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+
+	want := []User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}, {ID: 1, Name: "Carol"}}
+	if fmt.Sprint(dropped) != fmt.Sprint(want) {
+		t.Errorf("expected dropped %v in input order, got %v", want, dropped)
+	}
+}
+
+func TestMapByReport_Empty(t *testing.T) {
+	m, dropped := MapByReport([]int(nil), func(i int) int { return i }, func(i int) int { return i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+	if dropped != nil {
+		t.Errorf("expected nil dropped, got %v", dropped)
+	}
+}
+
+// ============== LoadOrStore 测试 ==============
+
+func TestLoadOrStore_Hit(t *testing.T) {
+	m := map[string]int{"a": 1}
+	called := 0
+	v, loaded := LoadOrStore(m, "a", func() int { called++; return 100 })
+	if v != 1 || !loaded {
+		t.Errorf("expected (1, true), got (%d, %v)", v, loaded)
+	}
+	if called != 0 {
+		t.Errorf("compute should not be called on hit, called %d times", called)
+	}
+	if m["a"] != 1 {
+		t.Errorf("existing value should be unchanged, got %d", m["a"])
+	}
+}
+
+func TestLoadOrStore_Miss(t *testing.T) {
+	m := map[string]int{}
+	called := 0
+	v, loaded := LoadOrStore(m, "b", func() int { called++; return 2 })
+	if v != 2 || loaded {
+		t.Errorf("expected (2, false), got (%d, %v)", v, loaded)
+	}
+	if called != 1 {
+		t.Errorf("compute should be called once on miss, called %d times", called)
+	}
+	if m["b"] != 2 {
+		t.Errorf("expected computed value to be stored, got %v", m)
+	}
+
+	// 第二次调用命中已存储的值
+	v, loaded = LoadOrStore(m, "b", func() int { called++; return 3 })
+	if v != 2 || !loaded {
+		t.Errorf("expected (2, true) on second call, got (%d, %v)", v, loaded)
+	}
+	if called != 1 {
+		t.Errorf("compute should not run again, called %d times", called)
+	}
+}
+
+func TestLoadOrStore_ZeroValueIsHit(t *testing.T) {
+	m := map[string]int{"zero": 0}
+	v, loaded := LoadOrStore(m, "zero", func() int { return 42 })
+	if v != 0 || !loaded {
+		t.Errorf("expected stored zero value to be loaded, got (%d, %v)", v, loaded)
+	}
+}
+
+func TestLoadOrStore_NilMapPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("LoadOrStore should panic on nil map")
+		}
+	}()
+	var m map[string]int
+	LoadOrStore(m, "a", func() int { return 1 })
+}
+
+// ============== MergeInto / MergeIntoFunc 测试 ==============
+
+func TestMergeInto(t *testing.T) {
+	dst := map[string]int{"a": 1, "x": 0}
+	src1 := map[string]int{"a": 10, "b": 2}
+	src2 := map[string]int{"b": 20, "c": 3}
+
+	MergeInto(dst, src1, nil, src2)
+
+	if !Equal(dst, map[string]int{"a": 10, "b": 20, "c": 3, "x": 0}) {
+		t.Errorf("unexpected result: %v", dst)
+	}
+	if !Equal(src1, map[string]int{"a": 10, "b": 2}) || !Equal(src2, map[string]int{"b": 20, "c": 3}) {
+		t.Errorf("sources should not be modified: %v %v", src1, src2)
+	}
+}
+
+func TestMergeInto_NoSources(t *testing.T) {
+	dst := map[string]int{"a": 1}
+	MergeInto(dst)
+	if !Equal(dst, map[string]int{"a": 1}) {
+		t.Errorf("dst should be unchanged, got %v", dst)
+	}
+}
+
+func TestMergeIntoFunc(t *testing.T) {
+	dst := map[string]int{"a": 1}
+	src1 := map[string]int{"a": 10, "b": 2}
+	src2 := map[string]int{"b": 20}
+
+	var conflicts []string
+	MergeIntoFunc(dst, func(k string, existing, incoming int) int {
+		conflicts = append(conflicts, fmt.Sprintf("%s:%d+%d", k, existing, incoming))
+		return existing + incoming
+	}, src1, src2)
+
+	if !Equal(dst, map[string]int{"a": 11, "b": 22}) {
+		t.Errorf("unexpected result: %v", dst)
+	}
+	if strings.Join(conflicts, ",") != "a:1+10,b:2+20" {
+		t.Errorf("resolver should only be called on conflicts, got %v", conflicts)
+	}
+	if !Equal(src1, map[string]int{"a": 10, "b": 2}) || !Equal(src2, map[string]int{"b": 20}) {
+		t.Errorf("sources should not be modified: %v %v", src1, src2)
+	}
+}
+
+// ============== FilterMap 测试 ==============
+
+func TestFilterMap_KeepAndTransform(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	r := FilterMap(m, func(k string, v int) (string, bool) {
+		return fmt.Sprintf("%s=%d", k, v*10), true
+	})
+	if !Equal(r, map[string]string{"a": "a=10", "b": "b=20"}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+}
+
+func TestFilterMap_DropSome(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	r := FilterMap(m, func(_ string, v int) (int, bool) { return v * v, v%2 == 0 })
+	if !Equal(r, map[string]int{"b": 4, "d": 16}) {
+		t.Errorf("unexpected result: %v", r)
+	}
+	if len(m) != 4 {
+		t.Errorf("source map should not be modified, got %v", m)
+	}
+}
+
+func TestFilterMap_DropAll(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	r := FilterMap(m, func(_ string, v int) (int, bool) { return v, false })
+	if r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+}
+
+func TestFilterMap_NilInput(t *testing.T) {
+	var m map[string]int
+	r := FilterMap(m, func(_ string, v int) (int, bool) { return v, true })
+	if r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", r)
+	}
+}
+
+// ============== MapByCap 测试 ==============
+
+func TestMapByCap_SameAsMapBy(t *testing.T) {
+	list := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	key := func(i int) int { return i % 3 }
+	value := func(i int) int { return i }
+
+	for _, capacity := range []int{-1, 0, 3, 100} {
+		m := MapByCap(list, capacity, key, value)
+		if !Equal(m, MapBy(list, key, value)) {
+			t.Errorf("capacity %d: expected same result as MapBy, got %v", capacity, m)
+		}
+	}
+}
+
+func TestMapByCap_Empty(t *testing.T) {
+	m := MapByCap([]int(nil), 10, func(i int) int { return i }, func(i int) int { return i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+}
+
+// ============== 基准测试 ==============
+
+// highDuplicateList 返回 n 个元素的切片，其中只有 distinct 个不同的键。
+func highDuplicateList(n, distinct int) []int {
+	list := make([]int, n)
+	for i := range list {
+		list[i] = i % distinct
+	}
+	return list
+}
+
+func BenchmarkMapBy_HighDuplicate(b *testing.B) {
+	list := highDuplicateList(100000, 100)
+	key := func(i int) int { return i }
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapBy(list, key, key)
+	}
+}
+
+func BenchmarkMapByCap_HighDuplicate(b *testing.B) {
+	list := highDuplicateList(100000, 100)
+	key := func(i int) int { return i }
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapByCap(list, 100, key, key)
+	}
+}
+
+// ============== RangeSortedE 测试 ==============
+
+func TestRangeSortedE_AscendingOrder(t *testing.T) {
+	m := map[int]string{3: "c", 1: "a", 2: "b", 5: "e", 4: "d"}
+	var keys []int
+	var values []string
+	err := RangeSortedE(m, func(k int, v string) error {
+		keys = append(keys, k)
+		values = append(values, v)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RangeSortedE should not return error: %v", err)
+	}
+	if fmt.Sprint(keys) != "[1 2 3 4 5]" || strings.Join(values, "") != "abcde" {
+		t.Errorf("expected ascending order, got keys %v values %v", keys, values)
+	}
+}
+
+func TestRangeSortedE_StopsOnError(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	stop := errors.New("stop")
+	var visited []string
+	err := RangeSortedE(m, func(k string, v int) error {
+		visited = append(visited, k)
+		if k == "b" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("expected first error to be returned, got %v", err)
+	}
+	if strings.Join(visited, ",") != "a,b" {
+		t.Errorf("expected no calls after the error, visited %v", visited)
+	}
+}
+
+func TestRangeSortedE_Empty(t *testing.T) {
+	called := false
+	err := RangeSortedE(map[string]int(nil), func(string, int) error {
+		called = true
+		return nil
+	})
+	if err != nil || called {
+		t.Errorf("expected no calls and nil error for nil map, got called=%v err=%v", called, err)
+	}
+}
+
+// ============== MapByIndexed 测试 ==============
+
+func TestMapByIndexed_IndexAsValue(t *testing.T) {
+	names := []string{"alice", "bob", "carol"}
+	m := MapByIndexed(names, func(_ int, s string) string { return s }, func(i int, _ string) int { return i })
+	if !Equal(m, map[string]int{"alice": 0, "bob": 1, "carol": 2}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+}
+
+func TestMapByIndexed_IndexInKey(t *testing.T) {
+	list := []string{"x", "x", "y"}
+	m := MapByIndexed(list,
+		func(i int, s string) string { return fmt.Sprintf("%d:%s", i, s) },
+		func(_ int, s string) string { return strings.ToUpper(s) },
+	)
+	if !Equal(m, map[string]string{"0:x": "X", "1:x": "X", "2:y": "Y"}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+}
+
+func TestMapByIndexed_DuplicateKeyLastWins(t *testing.T) {
+	list := []string{"a", "b", "a"}
+	m := MapByIndexed(list, func(_ int, s string) string { return s }, func(i int, _ string) int { return i })
+	if !Equal(m, map[string]int{"a": 2, "b": 1}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+}
+
+func TestMapByIndexed_Empty(t *testing.T) {
+	m := MapByIndexed([]int(nil), func(i, _ int) int { return i }, func(i, _ int) int { return i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+}
+
+// ============== MapByOrdered 测试 ==============
+
+func TestMapByOrdered_FirstSeenOrder(t *testing.T) {
+	list := []string{"banana", "apple", "blueberry", "cherry", "avocado"}
+	m, order := MapByOrdered(list, func(s string) string { return s[:1] }, func(s string) string { return s })
+
+	if !Equal(m, map[string]string{"b": "blueberry", "a": "avocado", "c": "cherry"}) {
+		t.Errorf("expected last-wins values, got %v", m)
+	}
+	if strings.Join(order, ",") != "b,a,c" {
+		t.Errorf("expected keys in first-seen order, got %v", order)
+	}
+}
+
+func TestMapByOrdered_NoDuplicateKeys(t *testing.T) {
+	list := []int{1, 2, 1, 3, 2, 1}
+	m, order := MapByOrdered(list, func(i int) int { return i }, func(i int) int { return i * 10 })
+	if len(order) != len(m) {
+		t.Errorf("expected one order entry per distinct key, got %v for %v", order, m)
+	}
+	if fmt.Sprint(order) != "[1 2 3]" {
+		t.Errorf("unexpected order: %v", order)
+	}
+}
+
+func TestMapByOrdered_Empty(t *testing.T) {
+	m, order := MapByOrdered([]int(nil), func(i int) int { return i }, func(i int) int { return i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+	if order == nil || len(order) != 0 {
+		t.Errorf("expected non-nil empty order, got %v", order)
+	}
+}
+
+// ============== GetFirst 测试 ==============
+
+func TestGetFirst_LaterKeyExists(t *testing.T) {
+	m := map[string]string{"db_host": "127.0.0.1"}
+	v, ok := GetFirst(m, "host", "db_host", "mysql_host")
+	if !ok || v != "127.0.0.1" {
+		t.Errorf("expected (127.0.0.1, true), got (%q, %v)", v, ok)
+	}
+}
+
+func TestGetFirst_FirstPresentWins(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	v, ok := GetFirst(m, "x", "b", "a", "c")
+	if !ok || v != 2 {
+		t.Errorf("expected (2, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestGetFirst_ZeroValuePresent(t *testing.T) {
+	m := map[string]int{"a": 0, "b": 2}
+	v, ok := GetFirst(m, "a", "b")
+	if !ok || v != 0 {
+		t.Errorf("expected stored zero value to win, got (%d, %v)", v, ok)
+	}
+}
+
+func TestGetFirst_NoneExist(t *testing.T) {
+	m := map[string]int{"a": 1}
+	if v, ok := GetFirst(m, "x", "y"); ok || v != 0 {
+		t.Errorf("expected (0, false), got (%d, %v)", v, ok)
+	}
+	if v, ok := GetFirst(m); ok || v != 0 {
+		t.Errorf("expected (0, false) without keys, got (%d, %v)", v, ok)
+	}
+	if _, ok := GetFirst[string, int](nil, "a"); ok {
+		t.Error("expected ok to be false for nil map")
+	}
+}
+
+// ============== Clear / Drain 测试 ==============
+
+func TestClear(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	Clear(m)
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", m)
+	}
+	m["c"] = 3
+	if len(m) != 1 {
+		t.Errorf("cleared map should be reusable, got %v", m)
+	}
+
+	var nilMap map[string]int
+	Clear(nilMap)
+}
+
+func TestDrain(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	got := Drain(m)
+	if !Equal(got, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("expected old contents, got %v", got)
+	}
+	if len(m) != 0 {
+		t.Errorf("expected source map to be empty, got %v", m)
+	}
+
+	m["c"] = 3
+	if len(got) != 2 {
+		t.Errorf("drained copy should not share storage with source, got %v", got)
+	}
+}
+
+func TestDrain_Nil(t *testing.T) {
+	got := Drain[string, int](nil)
+	if got == nil || len(got) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", got)
+	}
+}
+
+func TestMapBy2(t *testing.T) {
+	type user struct {
+		Region string
+		ID     int
+		Name   string
+	}
+	users := []user{
+		{Region: "cn", ID: 1, Name: "Alice"},
+		{Region: "us", ID: 2, Name: "Bob"},
+		{Region: "cn", ID: 3, Name: "Carol"},
+		{Region: "cn", ID: 1, Name: "Alice2"},
+	}
+	m := MapBy2(users,
+		func(u user) string { return u.Region },
+		func(u user) int { return u.ID },
+		func(u user) string { return u.Name },
+	)
+
+	if len(m) != 2 {
+		t.Fatalf("expected 2 regions, got %d", len(m))
+	}
+	if !Equal(m["cn"], map[int]string{1: "Alice2", 3: "Carol"}) {
+		t.Errorf("unexpected cn index: %v", m["cn"])
+	}
+	if !Equal(m["us"], map[int]string{2: "Bob"}) {
+		t.Errorf("unexpected us index: %v", m["us"])
+	}
+}
+
+func TestMapBy2_Empty(t *testing.T) {
+	m := MapBy2([]int(nil), func(i int) int { return i }, func(i int) int { return i }, func(i int) int { return i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", m)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}, {ID: 1, Name: "Alice2"}}
+	m := Index(users, func(u user) int { return u.ID })
+	if len(m) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(m))
+	}
+	if m[1] != (user{ID: 1, Name: "Alice2"}) {
+		t.Errorf("expected last element to win, got %v", m[1])
+	}
+	if m[2] != (user{ID: 2, Name: "Bob"}) {
+		t.Errorf("unexpected entry for key 2: %v", m[2])
+	}
+}
+
+func TestIndexUnique(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	m, err := IndexUnique([]user{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}, func(u user) int { return u.ID })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m) != 2 || m[1].Name != "Alice" || m[2].Name != "Bob" {
+		t.Errorf("unexpected index: %v", m)
+	}
+
+	_, err = IndexUnique([]user{{ID: 1, Name: "Alice"}, {ID: 1, Name: "Bob"}}, func(u user) int { return u.ID })
+	if !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("expected ErrDuplicateKey, got %v", err)
+	}
+}
+
+func TestCompactPtr(t *testing.T) {
+	a, b := 1, 2
+	m := map[string]*int{"a": &a, "nil1": nil, "b": &b, "nil2": nil}
+	got := CompactPtr(m)
+	if len(got) != 2 || got["a"] != &a || got["b"] != &b {
+		t.Errorf("expected only non-nil pointers, got %v", got)
+	}
+	if len(m) != 4 {
+		t.Errorf("source map should not be modified, got %v", m)
+	}
+
+	if got := CompactPtr[string, int](nil); got == nil || len(got) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", got)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	got := Compact(map[string]int{"a": 1, "zero": 0, "b": -2})
+	if !Equal(got, map[string]int{"a": 1, "b": -2}) {
+		t.Errorf("expected zero values dropped, got %v", got)
+	}
+
+	strs := Compact(map[int]string{1: "x", 2: "", 3: "y"})
+	if !Equal(strs, map[int]string{1: "x", 3: "y"}) {
+		t.Errorf("expected empty strings dropped, got %v", strs)
+	}
+
+	if got := Compact[string, int](nil); got == nil || len(got) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", got)
+	}
+}
+
+func TestMapGetFilter(t *testing.T) {
+	type user struct {
+		Name   string
+		Active bool
+	}
+	users := map[int]user{1: {Name: "Alice", Active: true}, 2: {Name: "Bob", Active: false}}
+	activeName := func(u user) (string, bool) { return u.Name, u.Active }
+
+	if v, ok := MapGetFilter(users, 3, activeName); ok || v != "" {
+		t.Errorf("absent key: expected (\"\", false), got (%q, %v)", v, ok)
+	}
+	if v, ok := MapGetFilter(users, 2, activeName); ok || v != "" {
+		t.Errorf("rejected value: expected (\"\", false), got (%q, %v)", v, ok)
+	}
+	if v, ok := MapGetFilter(users, 1, activeName); !ok || v != "Alice" {
+		t.Errorf("accepted value: expected (\"Alice\", true), got (%q, %v)", v, ok)
+	}
+}
+
+func TestCountDistinct(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry", "apple"}
+	if n := CountDistinct(words, func(s string) string { return s }); n != 5 {
+		t.Errorf("expected 5 distinct words, got %d", n)
+	}
+	if n := CountDistinct(words, func(s string) byte { return s[0] }); n != 3 {
+		t.Errorf("expected 3 distinct initials, got %d", n)
+	}
+	if n := CountDistinct([]string(nil), func(s string) string { return s }); n != 0 {
+		t.Errorf("expected 0 for nil slice, got %d", n)
+	}
+}
+
+func TestSumByKey(t *testing.T) {
+	type tx struct {
+		Account string
+		Amount  float64
+	}
+	txs := []tx{
+		{Account: "a", Amount: 10},
+		{Account: "b", Amount: 5.5},
+		{Account: "a", Amount: -3},
+		{Account: "c", Amount: 0},
+	}
+	sums := SumByKey(txs, func(t tx) string { return t.Account }, func(t tx) float64 { return t.Amount })
+	if !Equal(sums, map[string]float64{"a": 7, "b": 5.5, "c": 0}) {
+		t.Errorf("unexpected sums: %v", sums)
+	}
+
+	empty := SumByKey([]tx(nil), func(t tx) string { return t.Account }, func(t tx) float64 { return t.Amount })
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", empty)
+	}
+}
+
+func TestGroupReduce(t *testing.T) {
+	type order struct {
+		Customer string
+		Total    int
+	}
+	orders := []order{
+		{Customer: "a", Total: 10},
+		{Customer: "b", Total: 5},
+		{Customer: "a", Total: 3},
+		{Customer: "c", Total: 0},
+	}
+	byCustomer := func(o order) string { return o.Customer }
+
+	sums := GroupReduce(orders, byCustomer, func() int { return 0 }, func(acc int, o order) int { return acc + o.Total })
+	if !Equal(sums, map[string]int{"a": 13, "b": 5, "c": 0}) {
+		t.Errorf("unexpected per-key sums: %v", sums)
+	}
+
+	counts := GroupReduce(orders, byCustomer, func() int { return 0 }, func(acc int, _ order) int { return acc + 1 })
+	if !Equal(counts, map[string]int{"a": 2, "b": 1, "c": 1}) {
+		t.Errorf("unexpected per-key counts: %v", counts)
+	}
+
+	// 每组按切片顺序折叠
+	seq := GroupReduce(orders, byCustomer, func() []int { return nil }, func(acc []int, o order) []int { return append(acc, o.Total) })
+	if got := fmt.Sprint(seq["a"]); got != "[10 3]" {
+		t.Errorf("expected [10 3], got %s", got)
+	}
+}
+
+func TestGroupReduce_Empty(t *testing.T) {
+	m := GroupReduce([]int(nil), func(i int) int { return i }, func() int { return 0 }, func(acc, i int) int { return acc + i })
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", m)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ids := map[string]int{"alice": 1, "bob": 2}
+	got := Transform(ids, func(name string, id int) (int, string) { return id, strings.ToUpper(name) })
+	if !Equal(got, map[int]string{1: "ALICE", 2: "BOB"}) {
+		t.Errorf("unexpected result: %v", got)
+	}
+
+	if got := Transform[string, int](nil, func(k string, v int) (string, int) { return k, v }); got == nil || len(got) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", got)
+	}
+}
+
+func TestTransform_KeyCollision(t *testing.T) {
+	m := map[string]int{"a1": 1, "a2": 1, "b1": 2}
+	got := Transform(m, func(k string, v int) (string, int) { return k[:1], v })
+	if len(got) != 2 || got["a"] != 1 || got["b"] != 2 {
+		t.Errorf("expected colliding keys to collapse into one entry, got %v", got)
+	}
+}
+
+func TestTransformFilter(t *testing.T) {
+	scores := map[string]int{"alice": 90, "bob": 50, "carol": 75}
+	got := TransformFilter(scores, func(name string, s int) (string, float64, bool) {
+		return strings.ToUpper(name), float64(s) / 100, s >= 60
+	})
+	if !Equal(got, map[string]float64{"ALICE": 0.9, "CAROL": 0.75}) {
+		t.Errorf("unexpected result: %v", got)
+	}
+
+	none := TransformFilter(scores, func(name string, s int) (string, int, bool) { return name, s, false })
+	if none == nil || len(none) != 0 {
+		t.Errorf("expected empty non-nil map, got %v", none)
+	}
+}
@@ -0,0 +1,144 @@
+package maputil
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// OrderedMap 是保持插入顺序的泛型 map。
+//
+// 内部使用 map 存储数据，并使用切片记录键的插入顺序。
+// 更新已存在的键不会改变其位置；删除后重新写入的键会被追加到末尾。
+// OrderedMap 不是并发安全的。
+//
+// 类型参数:
+//   - K: 键类型
+//   - V: 值类型
+type OrderedMap[K comparable, V any] struct {
+	m    map[K]V // m 存储实际数据
+	keys []K     // keys 按插入顺序记录所有键
+}
+
+// NewOrderedMap 创建一个新的有序 map。
+//
+// 示例:
+//
+//	om := NewOrderedMap[string, int]()
+//	om.Set("b", 2)
+//	om.Set("a", 1)
+//	om.Keys()
+//	// []string{"b", "a"}
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{m: make(map[K]V)}
+}
+
+// Set 设置指定键的值。
+//
+// 若键已存在则只更新值，位置保持不变；否则将键追加到末尾。
+func (o *OrderedMap[K, V]) Set(key K, value V) {
+	if o.m == nil {
+		o.m = make(map[K]V)
+	}
+	if _, ok := o.m[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.m[key] = value
+}
+
+// Get 获取指定键的值。
+//
+// 返回值:
+//   - 第一个返回值为键对应的值，若键不存在则返回零值
+//   - 第二个返回值表示键是否存在
+func (o *OrderedMap[K, V]) Get(key K) (V, bool) {
+	v, ok := o.m[key]
+	return v, ok
+}
+
+// Delete 删除指定键，并从顺序记录中移除。
+//
+// 返回值表示键是否存在。
+func (o *OrderedMap[K, V]) Delete(key K) bool {
+	if _, ok := o.m[key]; !ok {
+		return false
+	}
+	delete(o.m, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Len 返回条目数量。
+func (o *OrderedMap[K, V]) Len() int {
+	return len(o.m)
+}
+
+// Keys 按插入顺序返回所有键。
+//
+// 返回的切片是副本，修改它不会影响 OrderedMap。
+func (o *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, len(o.keys))
+	copy(keys, o.keys)
+	return keys
+}
+
+// Range 按插入顺序遍历所有条目，fn 返回 false 时停止遍历。
+func (o *OrderedMap[K, V]) Range(fn func(K, V) bool) {
+	for _, k := range o.keys {
+		if !fn(k, o.m[k]) {
+			return
+		}
+	}
+}
+
+// MarshalJSON 将 OrderedMap 编码为 JSON 对象，字段按插入顺序输出。
+//
+// 键类型必须是 string 或实现了 encoding.TextMarshaler，否则返回错误。
+func (o *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := orderedMapKeyString(k)
+		if err != nil {
+			return nil, err
+		}
+		kb, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		vb, err := json.Marshal(o.m[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// orderedMapKeyString 将键转换为 JSON 对象的字段名。
+func orderedMapKeyString(k any) (string, error) {
+	switch v := k.(type) {
+	case string:
+		return v, nil
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return "", fmt.Errorf("bizutil.maputil: unsupported OrderedMap key type %T for JSON", k)
+	}
+}
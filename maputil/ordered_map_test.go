@@ -0,0 +1,134 @@
+package maputil
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// ============== OrderedMap 测试 ==============
+
+func assertKeys(t *testing.T, got, expected []string) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("expected keys %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected keys %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestOrderedMap_InsertionOrder(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("c", 3)
+	om.Set("a", 1)
+	om.Set("b", 2)
+	assertKeys(t, om.Keys(), []string{"c", "a", "b"})
+
+	if v, ok := om.Get("a"); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, ok)
+	}
+	if om.Len() != 3 {
+		t.Errorf("expected length 3, got %d", om.Len())
+	}
+}
+
+func TestOrderedMap_UpdateKeepsPosition(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("a", 1)
+	om.Set("b", 2)
+	om.Set("a", 10)
+	assertKeys(t, om.Keys(), []string{"a", "b"})
+	if v, _ := om.Get("a"); v != 10 {
+		t.Errorf("expected updated value 10, got %d", v)
+	}
+}
+
+func TestOrderedMap_DeleteAndReset(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("a", 1)
+	om.Set("b", 2)
+	om.Set("c", 3)
+
+	if !om.Delete("b") {
+		t.Error("expected Delete to return true for existing key")
+	}
+	if om.Delete("b") {
+		t.Error("expected Delete to return false for missing key")
+	}
+	assertKeys(t, om.Keys(), []string{"a", "c"})
+	if om.Len() != 2 {
+		t.Errorf("expected length 2, got %d", om.Len())
+	}
+
+	// 删除后重新写入，应追加到末尾
+	om.Set("b", 20)
+	assertKeys(t, om.Keys(), []string{"a", "c", "b"})
+}
+
+func TestOrderedMap_Range(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("x", 1)
+	om.Set("y", 2)
+	om.Set("z", 3)
+
+	var keys []string
+	om.Range(func(k string, _ int) bool {
+		keys = append(keys, k)
+		return k != "y"
+	})
+	assertKeys(t, keys, []string{"x", "y"})
+}
+
+func TestOrderedMap_KeysIsCopy(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("a", 1)
+	keys := om.Keys()
+	keys[0] = "changed"
+	assertKeys(t, om.Keys(), []string{"a"})
+}
+
+func TestOrderedMap_ZeroValue(t *testing.T) {
+	var om OrderedMap[string, int]
+	om.Set("a", 1)
+	if v, ok := om.Get("a"); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestOrderedMap_MarshalJSON(t *testing.T) {
+	om := NewOrderedMap[string, any]()
+	om.Set("zeta", 1)
+	om.Set("alpha", "a")
+	om.Set("mid", []int{1, 2})
+	om.Delete("alpha")
+	om.Set("alpha", true)
+
+	b, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("Marshal should not return error: %v", err)
+	}
+	expected := `{"zeta":1,"mid":[1,2],"alpha":true}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+}
+
+func TestOrderedMap_MarshalJSON_Empty(t *testing.T) {
+	b, err := json.Marshal(NewOrderedMap[string, int]())
+	if err != nil {
+		t.Fatalf("Marshal should not return error: %v", err)
+	}
+	if string(b) != "{}" {
+		t.Errorf("expected {}, got %s", b)
+	}
+}
+
+func TestOrderedMap_MarshalJSON_UnsupportedKey(t *testing.T) {
+	om := NewOrderedMap[int, int]()
+	om.Set(1, 1)
+	if _, err := json.Marshal(om); err == nil {
+		t.Error("expected error for non-string key type")
+	}
+}
@@ -0,0 +1,78 @@
+package maputil
+
+import "cmp"
+
+// Pair 表示 map 中的一个键值对。
+//
+// 类型参数:
+//   - K: 键类型
+//   - V: 值类型
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// FromPairs 将键值对切片转换为 map。
+//
+// 若多个键值对的键相同，后者覆盖前者。空切片或 nil 切片返回空 map（非 nil）。
+//
+// 示例:
+//
+//	m := FromPairs([]Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}})
+//	// m = map[string]int{"a": 1, "b": 2}
+func FromPairs[K comparable, V any](pairs []Pair[K, V]) map[K]V {
+	m := make(map[K]V, len(pairs))
+	for _, p := range pairs {
+		m[p.Key] = p.Value
+	}
+	return m
+}
+
+// Of 由可变参数形式的键值对构建 map，适用于测试数据或内联字面量。
+//
+// 与 FromPairs 相同，若多个键值对的键相同，后者覆盖前者；不传参数时返回空 map（非 nil）。
+//
+// 示例:
+//
+//	m := Of(Pair[string, int]{Key: "a", Value: 1}, Pair[string, int]{Key: "b", Value: 2})
+//	// m = map[string]int{"a": 1, "b": 2}
+func Of[K comparable, V any](pairs ...Pair[K, V]) map[K]V {
+	return FromPairs(pairs)
+}
+
+// ToPairs 将 map 转换为键值对切片。
+//
+// 返回切片的顺序不确定（依赖 map 遍历顺序）；需要确定顺序时请使用 ToPairsSorted。
+// 传入 nil map 时返回空切片（非 nil）。
+func ToPairs[K comparable, V any](m map[K]V) []Pair[K, V] {
+	return MapToSlice(m, func(k K, v V) Pair[K, V] { return Pair[K, V]{Key: k, Value: v} })
+}
+
+// ToPairsSorted 将 map 转换为按键升序排列的键值对切片。
+//
+// 示例:
+//
+//	pairs := ToPairsSorted(map[string]int{"b": 2, "a": 1})
+//	// pairs = []Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}
+func ToPairsSorted[K cmp.Ordered, V any](m map[K]V) []Pair[K, V] {
+	return MapToSliceSorted(m, func(k K, v V) Pair[K, V] { return Pair[K, V]{Key: k, Value: v} })
+}
+
+// Entries 以键值对切片的形式返回 map 中的所有条目，等价于 ToPairs。
+//
+// 与只返回键或值的函数不同，Entries 保留键与值的对应关系，适用于日志输出或表格渲染。
+// 返回切片的顺序不确定；需要确定顺序时请使用 EntriesSorted。
+func Entries[K comparable, V any](m map[K]V) []Pair[K, V] {
+	return ToPairs(m)
+}
+
+// EntriesSorted 以按键升序排列的键值对切片返回 map 中的所有条目，等价于 ToPairsSorted。
+//
+// 示例:
+//
+//	for _, e := range EntriesSorted(map[string]int{"b": 2, "a": 1}) {
+//	    fmt.Printf("%s=%d\n", e.Key, e.Value) // 依次输出 a=1、b=2
+//	}
+func EntriesSorted[K cmp.Ordered, V any](m map[K]V) []Pair[K, V] {
+	return ToPairsSorted(m)
+}
@@ -0,0 +1,112 @@
+package maputil
+
+import "testing"
+
+// ============== FromPairs / ToPairs / ToPairsSorted 测试 ==============
+
+func TestFromPairs_Basic(t *testing.T) {
+	m := FromPairs([]Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}})
+	if !Equal(m, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("unexpected result: %v", m)
+	}
+}
+
+func TestFromPairs_DuplicateKeys_LastWins(t *testing.T) {
+	m := FromPairs([]Pair[string, int]{
+		{Key: "a", Value: 1},
+		{Key: "b", Value: 2},
+		{Key: "a", Value: 3},
+	})
+	if !Equal(m, map[string]int{"a": 3, "b": 2}) {
+		t.Errorf("expected last-wins result, got %v", m)
+	}
+}
+
+func TestFromPairs_Nil(t *testing.T) {
+	m := FromPairs[string, int](nil)
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+}
+
+func TestToPairs_RoundTrip(t *testing.T) {
+	src := map[string]int{"a": 1, "b": 2, "c": 3}
+	pairs := ToPairs(src)
+	if len(pairs) != len(src) {
+		t.Fatalf("expected %d pairs, got %d", len(src), len(pairs))
+	}
+	if m := FromPairs(pairs); !Equal(m, src) {
+		t.Errorf("expected round-trip to preserve map, got %v", m)
+	}
+}
+
+func TestToPairs_Nil(t *testing.T) {
+	pairs := ToPairs[string, int](nil)
+	if pairs == nil || len(pairs) != 0 {
+		t.Errorf("expected non-nil empty slice, got %v", pairs)
+	}
+}
+
+func TestToPairsSorted_Order(t *testing.T) {
+	pairs := ToPairsSorted(map[string]int{"c": 3, "a": 1, "b": 2})
+	expected := []Pair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}}
+	if len(pairs) != len(expected) {
+		t.Fatalf("expected %d pairs, got %d", len(expected), len(pairs))
+	}
+	for i := range expected {
+		if pairs[i] != expected[i] {
+			t.Errorf("expected pairs[%d] = %v, got %v", i, expected[i], pairs[i])
+		}
+	}
+}
+
+// ============== Entries / EntriesSorted 测试 ==============
+
+func TestEntries_Content(t *testing.T) {
+	src := map[string]int{"a": 1, "b": 2, "c": 3}
+	entries := Entries(src)
+	if len(entries) != len(src) {
+		t.Fatalf("expected %d entries, got %d", len(src), len(entries))
+	}
+	for _, e := range entries {
+		if v, ok := src[e.Key]; !ok || v != e.Value {
+			t.Errorf("entry %v does not match source map", e)
+		}
+	}
+	if e := Entries[string, int](nil); e == nil || len(e) != 0 {
+		t.Errorf("expected non-nil empty slice for nil map, got %v", e)
+	}
+}
+
+func TestEntriesSorted_Order(t *testing.T) {
+	entries := EntriesSorted(map[int]string{3: "c", 1: "a", 2: "b"})
+	expected := []Pair[int, string]{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}, {Key: 3, Value: "c"}}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("expected entries[%d] = %v, got %v", i, expected[i], entries[i])
+		}
+	}
+}
+
+// ============== Of 测试 ==============
+
+func TestOf_DuplicateKeys_LastWins(t *testing.T) {
+	m := Of(
+		Pair[string, int]{Key: "a", Value: 1},
+		Pair[string, int]{Key: "b", Value: 2},
+		Pair[string, int]{Key: "a", Value: 3},
+	)
+	if !Equal(m, map[string]int{"a": 3, "b": 2}) {
+		t.Errorf("expected last-wins result, got %v", m)
+	}
+}
+
+func TestOf_Empty(t *testing.T) {
+	m := Of[string, int]()
+	if m == nil || len(m) != 0 {
+		t.Errorf("expected non-nil empty map, got %v", m)
+	}
+}
@@ -0,0 +1,236 @@
+package maputil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GetPath 按键路径逐层查找嵌套 map 中的值。
+//
+// 适用于从 JSON/YAML 解析得到的 map[string]any 树中读取深层配置。
+// 路径中的每一段（最后一段除外）都必须对应一个 map[string]any。
+//
+// 参数:
+//   - m: 源 map，可以为 nil
+//   - path: 键路径，为空时返回 m 本身
+//
+// 返回值:
+//   - 第一个返回值为路径末端的值
+//   - 第二个返回值表示路径是否完整存在；任意一段缺失或中途遇到非 map 值时返回 false
+//
+// 示例:
+//
+//	m := map[string]any{"db": map[string]any{"host": "localhost"}}
+//	v, ok := GetPath(m, "db", "host")
+//	// v = "localhost", ok = true
+func GetPath(m map[string]any, path ...string) (any, bool) {
+	var cur any = m
+	for _, key := range path {
+		node, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = node[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// GetPathAs 按键路径查找嵌套 map 中的值，并将其断言为类型 T。
+//
+// 返回值:
+//   - 第一个返回值为断言后的值，路径不存在或类型不匹配时返回零值
+//   - 第二个返回值表示路径存在且类型匹配
+//
+// 示例:
+//
+//	m := map[string]any{"db": map[string]any{"port": 3306}}
+//	port, ok := GetPathAs[int](m, "db", "port")
+//	// port = 3306, ok = true
+func GetPathAs[T any](m map[string]any, path ...string) (T, bool) {
+	v, ok := GetPath(m, path...)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	t, ok := v.(T)
+	return t, ok
+}
+
+// SetPath 按键路径向嵌套 map 写入值，缺失的中间层级会自动创建为 map[string]any。
+//
+// SetPath 会原地修改 m。路径只有一段时等价于 m[path[0]] = value。
+//
+// 参数:
+//   - m: 目标 map，必须非 nil
+//   - value: 要写入的值
+//   - path: 键路径，不能为空
+//
+// 可能返回的错误:
+//   - ErrEmptyPath: path 为空
+//   - ErrPathNotMap: 某个中间节点已存在但不是 map[string]any
+//
+// 示例:
+//
+//	m := map[string]any{}
+//	err := SetPath(m, "localhost", "db", "host")
+//	// m = map[string]any{"db": map[string]any{"host": "localhost"}}
+func SetPath(m map[string]any, value any, path ...string) error {
+	if len(path) == 0 {
+		return ErrEmptyPath
+	}
+	node := m
+	for i, key := range path[:len(path)-1] {
+		v, ok := node[key]
+		if !ok {
+			child := make(map[string]any)
+			node[key] = child
+			node = child
+			continue
+		}
+		child, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("set path %q: segment %q is %T: %w", strings.Join(path, "."), strings.Join(path[:i+1], "."), v, ErrPathNotMap)
+		}
+		node = child
+	}
+	node[path[len(path)-1]] = value
+	return nil
+}
+
+// GetString 按键路径查找嵌套 map 中的字符串值。
+//
+// 路径不存在或值不是 string 时返回 ok=false。
+//
+// 示例:
+//
+//	host, ok := GetString(m, "db", "host")
+func GetString(m map[string]any, path ...string) (string, bool) {
+	return GetPathAs[string](m, path...)
+}
+
+// GetBool 按键路径查找嵌套 map 中的布尔值。
+//
+// 路径不存在或值不是 bool 时返回 ok=false。
+func GetBool(m map[string]any, path ...string) (bool, bool) {
+	return GetPathAs[bool](m, path...)
+}
+
+// GetInt 按键路径查找嵌套 map 中的整数值。
+//
+// 除 int 及其他整数类型外，还兼容 encoding/json 解码得到的 float64 和 json.Number，
+// 但要求其值为整数且不超出 int 的范围；否则返回 ok=false。
+//
+// 示例:
+//
+//	var m map[string]any
+//	_ = json.Unmarshal([]byte(`{"db":{"port":3306}}`), &m)
+//	port, ok := GetInt(m, "db", "port")
+//	// port = 3306, ok = true（JSON 中的数字解码为 float64）
+func GetInt(m map[string]any, path ...string) (int, bool) {
+	v, ok := GetPath(m, path...)
+	if !ok {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		if n < math.MinInt || n > math.MaxInt {
+			return 0, false
+		}
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		if uint64(n) > math.MaxInt {
+			return 0, false
+		}
+		return int(n), true
+	case uint:
+		if uint64(n) > math.MaxInt {
+			return 0, false
+		}
+		return int(n), true
+	case uint64:
+		if n > math.MaxInt {
+			return 0, false
+		}
+		return int(n), true
+	case float32:
+		return floatToInt(float64(n))
+	case float64:
+		return floatToInt(n)
+	case json.Number:
+		i, err := n.Int64()
+		if err != nil || i < math.MinInt || i > math.MaxInt {
+			return 0, false
+		}
+		return int(i), true
+	default:
+		return 0, false
+	}
+}
+
+// GetFloat 按键路径查找嵌套 map 中的浮点数值。
+//
+// 除 float64 外，还兼容 float32、各整数类型和 json.Number；其他类型返回 ok=false。
+func GetFloat(m map[string]any, path ...string) (float64, bool) {
+	v, ok := GetPath(m, path...)
+	if !ok {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+// floatToInt 将整数值的浮点数转换为 int，非整数或超出 int 范围时返回 ok=false。
+func floatToInt(f float64) (int, bool) {
+	if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+		return 0, false
+	}
+	return int(f), true
+}
@@ -0,0 +1,264 @@
+package maputil
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newPathTestMap() map[string]any {
+	return map[string]any{
+		"name": "app",
+		"db": map[string]any{
+			"host": "localhost",
+			"port": 3306,
+			"options": map[string]any{
+				"timeout": "5s",
+			},
+		},
+	}
+}
+
+// ============== GetPath / GetPathAs 测试 ==============
+
+func TestGetPath_DeepPath(t *testing.T) {
+	m := newPathTestMap()
+	v, ok := GetPath(m, "db", "options", "timeout")
+	if !ok {
+		t.Fatal("expected ok to be true")
+	}
+	if v != "5s" {
+		t.Errorf("expected '5s', got %v", v)
+	}
+}
+
+func TestGetPath_IntermediateMap(t *testing.T) {
+	m := newPathTestMap()
+	v, ok := GetPath(m, "db", "options")
+	if !ok {
+		t.Fatal("expected ok to be true")
+	}
+	if _, isMap := v.(map[string]any); !isMap {
+		t.Errorf("expected map[string]any, got %T", v)
+	}
+}
+
+func TestGetPath_MissingSegment(t *testing.T) {
+	m := newPathTestMap()
+	if _, ok := GetPath(m, "db", "missing", "timeout"); ok {
+		t.Error("expected ok to be false for missing segment")
+	}
+	if _, ok := GetPath(m, "db", "options", "missing"); ok {
+		t.Error("expected ok to be false for missing leaf")
+	}
+}
+
+func TestGetPath_NonMapIntermediate(t *testing.T) {
+	m := newPathTestMap()
+	if _, ok := GetPath(m, "name", "x"); ok {
+		t.Error("expected ok to be false when descending into a non-map value")
+	}
+}
+
+func TestGetPath_NilMap(t *testing.T) {
+	if _, ok := GetPath(nil, "a"); ok {
+		t.Error("expected ok to be false for nil map")
+	}
+}
+
+func TestGetPathAs_Success(t *testing.T) {
+	m := newPathTestMap()
+	port, ok := GetPathAs[int](m, "db", "port")
+	if !ok {
+		t.Fatal("expected ok to be true")
+	}
+	if port != 3306 {
+		t.Errorf("expected 3306, got %d", port)
+	}
+}
+
+func TestGetPathAs_TypeMismatch(t *testing.T) {
+	m := newPathTestMap()
+	v, ok := GetPathAs[string](m, "db", "port")
+	if ok {
+		t.Error("expected ok to be false for type mismatch")
+	}
+	if v != "" {
+		t.Errorf("expected zero value, got %q", v)
+	}
+}
+
+func TestGetPathAs_Missing(t *testing.T) {
+	m := newPathTestMap()
+	v, ok := GetPathAs[int](m, "db", "missing")
+	if ok {
+		t.Error("expected ok to be false for missing path")
+	}
+	if v != 0 {
+		t.Errorf("expected zero value, got %d", v)
+	}
+}
+
+// ============== SetPath 测试 ==============
+
+func TestSetPath_CreateDeepPath(t *testing.T) {
+	m := map[string]any{}
+	if err := SetPath(m, "localhost", "db", "primary", "host"); err != nil {
+		t.Fatalf("SetPath should not return error: %v", err)
+	}
+	v, ok := GetPathAs[string](m, "db", "primary", "host")
+	if !ok || v != "localhost" {
+		t.Errorf("expected ('localhost', true), got (%q, %v)", v, ok)
+	}
+}
+
+func TestSetPath_OverwriteLeaf(t *testing.T) {
+	m := newPathTestMap()
+	if err := SetPath(m, 3307, "db", "port"); err != nil {
+		t.Fatalf("SetPath should not return error: %v", err)
+	}
+	port, _ := GetPathAs[int](m, "db", "port")
+	if port != 3307 {
+		t.Errorf("expected 3307, got %d", port)
+	}
+	// 同级的其他键不受影响
+	if host, _ := GetPathAs[string](m, "db", "host"); host != "localhost" {
+		t.Errorf("expected sibling key to be preserved, got %q", host)
+	}
+}
+
+func TestSetPath_SingleSegment(t *testing.T) {
+	m := map[string]any{}
+	if err := SetPath(m, 1, "a"); err != nil {
+		t.Fatalf("SetPath should not return error: %v", err)
+	}
+	if m["a"] != 1 {
+		t.Errorf("expected m['a'] = 1, got %v", m["a"])
+	}
+}
+
+func TestSetPath_NonMapIntermediate(t *testing.T) {
+	m := newPathTestMap()
+	err := SetPath(m, "x", "name", "first")
+	if err == nil {
+		t.Fatal("SetPath should return error when an intermediate value is not a map")
+	}
+	if !errors.Is(err, ErrPathNotMap) {
+		t.Errorf("expected ErrPathNotMap, got %v", err)
+	}
+	if m["name"] != "app" {
+		t.Errorf("expected existing value to be preserved, got %v", m["name"])
+	}
+}
+
+func TestSetPath_EmptyPath(t *testing.T) {
+	err := SetPath(map[string]any{}, 1)
+	if !errors.Is(err, ErrEmptyPath) {
+		t.Errorf("expected ErrEmptyPath, got %v", err)
+	}
+}
+
+func TestTypedGetters_JSONDocument(t *testing.T) {
+	doc := `{
+		"name": "app",
+		"debug": true,
+		"ratio": 0.75,
+		"db": {
+			"host": "localhost",
+			"port": 3306,
+			"replica": {"enabled": false, "weight": 2.5, "lag": -1}
+		}
+	}`
+	var m map[string]any
+	if err := json.Unmarshal([]byte(doc), &m); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	if v, ok := GetString(m, "name"); !ok || v != "app" {
+		t.Errorf("GetString(name) = (%q, %v)", v, ok)
+	}
+	if v, ok := GetString(m, "db", "host"); !ok || v != "localhost" {
+		t.Errorf("GetString(db.host) = (%q, %v)", v, ok)
+	}
+	if v, ok := GetBool(m, "debug"); !ok || !v {
+		t.Errorf("GetBool(debug) = (%v, %v)", v, ok)
+	}
+	if v, ok := GetBool(m, "db", "replica", "enabled"); !ok || v {
+		t.Errorf("GetBool(db.replica.enabled) = (%v, %v)", v, ok)
+	}
+
+	// JSON 数字解码为 float64，GetInt 应能读取整数值
+	if v, ok := GetInt(m, "db", "port"); !ok || v != 3306 {
+		t.Errorf("GetInt(db.port) = (%d, %v)", v, ok)
+	}
+	if v, ok := GetInt(m, "db", "replica", "lag"); !ok || v != -1 {
+		t.Errorf("GetInt(db.replica.lag) = (%d, %v)", v, ok)
+	}
+	if v, ok := GetFloat(m, "db", "replica", "weight"); !ok || v != 2.5 {
+		t.Errorf("GetFloat(db.replica.weight) = (%v, %v)", v, ok)
+	}
+	if v, ok := GetFloat(m, "db", "port"); !ok || v != 3306 {
+		t.Errorf("GetFloat(db.port) = (%v, %v)", v, ok)
+	}
+}
+
+func TestTypedGetters_MismatchAndMissing(t *testing.T) {
+	m := map[string]any{
+		"name":  "app",
+		"ratio": 0.75,
+		"port":  3306,
+		"huge":  1e300,
+		"db":    map[string]any{"port": "3306"},
+	}
+
+	if _, ok := GetString(m, "port"); ok {
+		t.Error("GetString should fail on int value")
+	}
+	if _, ok := GetBool(m, "name"); ok {
+		t.Error("GetBool should fail on string value")
+	}
+	if _, ok := GetInt(m, "ratio"); ok {
+		t.Error("GetInt should fail on non-integral float")
+	}
+	if _, ok := GetInt(m, "huge"); ok {
+		t.Error("GetInt should fail on out-of-range float")
+	}
+	if _, ok := GetInt(m, "db", "port"); ok {
+		t.Error("GetInt should fail on string value")
+	}
+	if _, ok := GetFloat(m, "name"); ok {
+		t.Error("GetFloat should fail on string value")
+	}
+	if _, ok := GetString(m, "missing"); ok {
+		t.Error("GetString should fail on missing path")
+	}
+	if _, ok := GetInt(m, "name", "nested"); ok {
+		t.Error("GetInt should fail when path passes through a non-map")
+	}
+	if v, ok := GetInt(m, "port"); !ok || v != 3306 {
+		t.Errorf("GetInt(port) = (%d, %v)", v, ok)
+	}
+	if v, ok := GetFloat(m, "port"); !ok || v != 3306 {
+		t.Errorf("GetFloat(port) = (%v, %v)", v, ok)
+	}
+}
+
+func TestTypedGetters_JSONNumber(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"id": 9007199254740993, "price": 9.99}`))
+	dec.UseNumber()
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+
+	if v, ok := GetInt(m, "id"); !ok || v != 9007199254740993 {
+		t.Errorf("GetInt(id) = (%d, %v)", v, ok)
+	}
+	if _, ok := GetInt(m, "price"); ok {
+		t.Error("GetInt should fail on non-integral json.Number")
+	}
+	if v, ok := GetFloat(m, "price"); !ok || v != 9.99 {
+		t.Errorf("GetFloat(price) = (%v, %v)", v, ok)
+	}
+}
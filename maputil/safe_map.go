@@ -0,0 +1,106 @@
+package maputil
+
+import "sync"
+
+// SafeMap 是并发安全的泛型 map，内部使用 sync.RWMutex 保护。
+//
+// SafeMap 的零值可直接使用，也可以通过 NewSafeMap 创建。
+// SafeMap 在首次使用后不能被复制。
+//
+// 类型参数:
+//   - K: 键类型
+//   - V: 值类型
+type SafeMap[K comparable, V any] struct {
+	mu sync.RWMutex // mu 用于保护并发访问
+	m  map[K]V      // m 存储实际数据，首次写入时惰性创建
+}
+
+// NewSafeMap 创建一个新的并发安全 map。
+//
+// 示例:
+//
+//	sm := NewSafeMap[string, int]()
+//	sm.Set("a", 1)
+//	v, ok := sm.Get("a")
+//	// v = 1, ok = true
+func NewSafeMap[K comparable, V any]() *SafeMap[K, V] {
+	return &SafeMap[K, V]{m: make(map[K]V)}
+}
+
+// Get 获取指定键的值。
+//
+// 返回值:
+//   - 第一个返回值为键对应的值，若键不存在则返回零值
+//   - 第二个返回值表示键是否存在
+func (s *SafeMap[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[key]
+	return v, ok
+}
+
+// Set 设置指定键的值，若键已存在则覆盖。
+func (s *SafeMap[K, V]) Set(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	s.m[key] = value
+}
+
+// Delete 删除指定键，键不存在时不做任何操作。
+func (s *SafeMap[K, V]) Delete(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+}
+
+// Len 返回 map 中的条目数量。
+func (s *SafeMap[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.m)
+}
+
+// Range 遍历 map 中的所有条目，fn 返回 false 时停止遍历。
+//
+// Range 在读锁下获取当前内容的快照后立即释放锁，再对快照调用 fn，
+// 因此 fn 中可以安全地调用 SafeMap 的其他方法而不会死锁；
+// 但遍历期间的修改不会反映到本次遍历中。遍历顺序不确定。
+func (s *SafeMap[K, V]) Range(fn func(K, V) bool) {
+	for k, v := range s.Clone() {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// LoadOrStore 若键已存在则返回现有值，否则存储并返回给定值。
+//
+// 返回值:
+//   - actual: 键对应的最终值
+//   - loaded: true 表示返回的是已存在的值，false 表示本次存储了 value
+func (s *SafeMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; ok {
+		return v, true
+	}
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// Clone 返回当前内容的浅拷贝，返回的 map 与 SafeMap 不共享存储。
+func (s *SafeMap[K, V]) Clone() map[K]V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r := make(map[K]V, len(s.m))
+	for k, v := range s.m {
+		r[k] = v
+	}
+	return r
+}
@@ -0,0 +1,159 @@
+package maputil
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSafeMap_Basic(t *testing.T) {
+	sm := NewSafeMap[string, int]()
+
+	if _, ok := sm.Get("a"); ok {
+		t.Error("expected ok to be false for missing key")
+	}
+
+	sm.Set("a", 1)
+	sm.Set("b", 2)
+	if v, ok := sm.Get("a"); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, ok)
+	}
+	if sm.Len() != 2 {
+		t.Errorf("expected length 2, got %d", sm.Len())
+	}
+
+	sm.Set("a", 10)
+	if v, _ := sm.Get("a"); v != 10 {
+		t.Errorf("expected overwritten value 10, got %d", v)
+	}
+
+	sm.Delete("a")
+	sm.Delete("notexist")
+	if _, ok := sm.Get("a"); ok {
+		t.Error("expected key 'a' to be deleted")
+	}
+	if sm.Len() != 1 {
+		t.Errorf("expected length 1, got %d", sm.Len())
+	}
+}
+
+func TestSafeMap_ZeroValue(t *testing.T) {
+	var sm SafeMap[string, int]
+	if sm.Len() != 0 {
+		t.Errorf("expected length 0, got %d", sm.Len())
+	}
+	sm.Delete("a")
+	sm.Set("a", 1)
+	if v, ok := sm.Get("a"); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestSafeMap_LoadOrStore(t *testing.T) {
+	sm := NewSafeMap[string, int]()
+
+	v, loaded := sm.LoadOrStore("a", 1)
+	if loaded {
+		t.Error("expected loaded to be false on first store")
+	}
+	if v != 1 {
+		t.Errorf("expected stored value 1, got %d", v)
+	}
+
+	v, loaded = sm.LoadOrStore("a", 2)
+	if !loaded {
+		t.Error("expected loaded to be true for existing key")
+	}
+	if v != 1 {
+		t.Errorf("expected existing value 1, got %d", v)
+	}
+}
+
+func TestSafeMap_Range(t *testing.T) {
+	sm := NewSafeMap[string, int]()
+	sm.Set("a", 1)
+	sm.Set("b", 2)
+	sm.Set("c", 3)
+
+	sum := 0
+	sm.Range(func(_ string, v int) bool {
+		sum += v
+		return true
+	})
+	if sum != 6 {
+		t.Errorf("expected sum 6, got %d", sum)
+	}
+
+	calls := 0
+	sm.Range(func(_ string, _ int) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("expected Range to stop after 1 call, got %d", calls)
+	}
+}
+
+func TestSafeMap_RangeCallbackMayWrite(t *testing.T) {
+	sm := NewSafeMap[string, int]()
+	sm.Set("a", 1)
+	sm.Set("b", 2)
+
+	// 回调中写入不应死锁
+	sm.Range(func(k string, v int) bool {
+		sm.Set(k+k, v*10)
+		return true
+	})
+	if sm.Len() != 4 {
+		t.Errorf("expected length 4, got %d", sm.Len())
+	}
+}
+
+func TestSafeMap_Clone(t *testing.T) {
+	sm := NewSafeMap[string, int]()
+	sm.Set("a", 1)
+
+	c := sm.Clone()
+	c["a"] = 100
+	c["b"] = 2
+	if v, _ := sm.Get("a"); v != 1 {
+		t.Errorf("expected source value 1, got %d", v)
+	}
+	if sm.Len() != 1 {
+		t.Errorf("expected source length 1, got %d", sm.Len())
+	}
+}
+
+func TestSafeMap_Concurrent(t *testing.T) {
+	sm := NewSafeMap[string, int]()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(4)
+		go func(i int) {
+			defer wg.Done()
+			sm.Set(fmt.Sprintf("key%d", i), i)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			sm.Get(fmt.Sprintf("key%d", i))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			sm.Delete(fmt.Sprintf("key%d", i-1))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			sm.LoadOrStore(fmt.Sprintf("shared%d", i%5), i)
+			sm.Range(func(_ string, _ int) bool { return true })
+			sm.Len()
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 5; i++ {
+		if _, ok := sm.Get(fmt.Sprintf("shared%d", i)); !ok {
+			t.Errorf("expected shared%d to be stored", i)
+		}
+	}
+}
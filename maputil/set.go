@@ -0,0 +1,135 @@
+package maputil
+
+// Set 是基于 map[K]struct{} 的泛型集合。
+//
+// Set 的零值可直接使用，也可以通过 NewSet 创建。
+// Set 不是并发安全的，需要并发访问时请自行加锁或使用 SafeMap。
+//
+// 类型参数:
+//   - K: 元素类型
+type Set[K comparable] struct {
+	m map[K]struct{} // m 存储集合元素，首次写入时惰性创建
+}
+
+// NewSet 创建一个包含指定元素的集合，重复元素只保留一个。
+//
+// 示例:
+//
+//	s := NewSet("a", "b", "a")
+//	// s.Len() = 2
+func NewSet[K comparable](items ...K) *Set[K] {
+	s := &Set[K]{m: make(map[K]struct{}, len(items))}
+	for _, item := range items {
+		s.m[item] = struct{}{}
+	}
+	return s
+}
+
+// Add 向集合中添加元素，元素已存在时不做任何操作。
+func (s *Set[K]) Add(items ...K) {
+	if s.m == nil {
+		s.m = make(map[K]struct{}, len(items))
+	}
+	for _, item := range items {
+		s.m[item] = struct{}{}
+	}
+}
+
+// Remove 从集合中删除元素，元素不存在时不做任何操作。
+func (s *Set[K]) Remove(items ...K) {
+	for _, item := range items {
+		delete(s.m, item)
+	}
+}
+
+// Contains 判断集合是否包含指定元素。
+func (s *Set[K]) Contains(item K) bool {
+	_, ok := s.m[item]
+	return ok
+}
+
+// Len 返回集合中的元素数量。
+func (s *Set[K]) Len() int {
+	return len(s.m)
+}
+
+// Slice 以切片形式返回集合中的所有元素，顺序不确定；返回的切片始终非 nil。
+func (s *Set[K]) Slice() []K {
+	r := make([]K, 0, len(s.m))
+	for k := range s.m {
+		r = append(r, k)
+	}
+	return r
+}
+
+// Union 返回包含 s 和 other 所有元素的新集合，两者均不会被修改。
+// other 为 nil 时视为空集合。
+//
+// 示例:
+//
+//	NewSet(1, 2).Union(NewSet(2, 3)) // {1, 2, 3}
+func (s *Set[K]) Union(other *Set[K]) *Set[K] {
+	return &Set[K]{m: Union(s.elems(), other.elems())}
+}
+
+// Intersect 返回同时存在于 s 和 other 中的元素组成的新集合，两者均不会被修改。
+// other 为 nil 时视为空集合。
+//
+// 示例:
+//
+//	NewSet(1, 2).Intersect(NewSet(2, 3)) // {2}
+func (s *Set[K]) Intersect(other *Set[K]) *Set[K] {
+	return &Set[K]{m: Intersect(s.elems(), other.elems())}
+}
+
+// Difference 返回存在于 s 但不存在于 other 中的元素组成的新集合，两者均不会被修改。
+// other 为 nil 时视为空集合。
+//
+// 示例:
+//
+//	NewSet(1, 2).Difference(NewSet(2, 3)) // {1}
+func (s *Set[K]) Difference(other *Set[K]) *Set[K] {
+	return &Set[K]{m: Subtract(s.elems(), other.elems())}
+}
+
+// elems 返回集合的底层 map，nil 集合视为空集合。
+func (s *Set[K]) elems() map[K]struct{} {
+	if s == nil {
+		return nil
+	}
+	return s.m
+}
+
+// SliceToSet 将切片转换为集合，重复元素只保留一个。
+//
+// 与 NewSet 不同，SliceToSet 返回集合值而非指针，便于直接作为结构体字段或局部变量使用；
+// 集合方法均为指针接收者，对可寻址的变量可直接调用。
+//
+// 示例:
+//
+//	s := SliceToSet([]string{"a", "b", "a"})
+//	// s.Len() = 2
+func SliceToSet[K comparable](items []K) Set[K] {
+	return *NewSet(items...)
+}
+
+// Unique 返回去重后的切片，保留每个元素首次出现的顺序。
+//
+// 源切片不会被修改；空切片或 nil 切片返回空切片（非 nil）。
+//
+// 示例:
+//
+//	r := Unique([]int{3, 1, 3, 2, 1})
+//	// r = []int{3, 1, 2}
+func Unique[K comparable](items []K) []K {
+	seen := make(map[K]struct{}, len(items))
+	r := make([]K, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		r = append(r, item)
+	}
+	return r
+}
@@ -0,0 +1,156 @@
+package maputil
+
+import (
+	"slices"
+	"testing"
+)
+
+func sortedSlice(s *Set[int]) []int {
+	r := s.Slice()
+	slices.Sort(r)
+	return r
+}
+
+func TestSet_Basic(t *testing.T) {
+	s := NewSet(1, 2, 2, 3)
+	if s.Len() != 3 {
+		t.Errorf("expected length 3, got %d", s.Len())
+	}
+	if !s.Contains(2) || s.Contains(4) {
+		t.Error("unexpected Contains result")
+	}
+
+	s.Add(4)
+	s.Add(4)
+	if s.Len() != 4 {
+		t.Errorf("Add should be idempotent, got length %d", s.Len())
+	}
+
+	s.Remove(1)
+	s.Remove(1)
+	s.Remove(100)
+	if s.Len() != 3 || s.Contains(1) {
+		t.Errorf("Remove should be idempotent, got %v", sortedSlice(s))
+	}
+
+	if got := sortedSlice(s); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("unexpected Slice result: %v", got)
+	}
+}
+
+func TestSet_ZeroValue(t *testing.T) {
+	var s Set[string]
+	if s.Contains("a") || s.Len() != 0 {
+		t.Error("zero value should be an empty set")
+	}
+	if r := s.Slice(); r == nil || len(r) != 0 {
+		t.Errorf("expected non-nil empty slice, got %v", r)
+	}
+	s.Remove("a")
+	s.Add("a", "b")
+	if s.Len() != 2 || !s.Contains("a") {
+		t.Errorf("zero value should be usable after Add, got len %d", s.Len())
+	}
+}
+
+func TestSet_Algebra(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(2, 3, 4)
+
+	if got := sortedSlice(a.Union(b)); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("unexpected Union: %v", got)
+	}
+	if got := sortedSlice(a.Intersect(b)); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("unexpected Intersect: %v", got)
+	}
+	if got := sortedSlice(a.Difference(b)); !slices.Equal(got, []int{1}) {
+		t.Errorf("unexpected Difference: %v", got)
+	}
+	if got := sortedSlice(b.Difference(a)); !slices.Equal(got, []int{4}) {
+		t.Errorf("unexpected Difference: %v", got)
+	}
+
+	// 源集合不应被修改
+	if got := sortedSlice(a); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("source set modified: %v", got)
+	}
+	if got := sortedSlice(b); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("source set modified: %v", got)
+	}
+
+	// 结果集合与源集合互不影响
+	u := a.Union(b)
+	u.Add(100)
+	if a.Contains(100) || b.Contains(100) {
+		t.Error("result set should not share storage with sources")
+	}
+}
+
+func TestSet_AlgebraWithEmpty(t *testing.T) {
+	a := NewSet(1, 2)
+	var empty Set[int]
+
+	if got := sortedSlice(a.Union(&empty)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("unexpected Union: %v", got)
+	}
+	if a.Intersect(&empty).Len() != 0 {
+		t.Error("intersection with empty set should be empty")
+	}
+	if got := sortedSlice(a.Difference(&empty)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("unexpected Difference: %v", got)
+	}
+}
+
+func TestSet_AlgebraWithNil(t *testing.T) {
+	a := NewSet(1, 2)
+
+	if got := sortedSlice(a.Union(nil)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("unexpected Union: %v", got)
+	}
+	if a.Intersect(nil).Len() != 0 {
+		t.Error("intersection with nil set should be empty")
+	}
+	if got := sortedSlice(a.Difference(nil)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("unexpected Difference: %v", got)
+	}
+	if a.Len() != 2 {
+		t.Errorf("receiver should not be modified, got %v", sortedSlice(a))
+	}
+}
+
+func TestSliceToSet(t *testing.T) {
+	s := SliceToSet([]int{3, 1, 3, 2, 1})
+	if got := sortedSlice(&s); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("unexpected set: %v", got)
+	}
+	s.Add(4)
+	if !s.Contains(4) || s.Len() != 4 {
+		t.Errorf("expected returned set value to be usable, got %v", s.Slice())
+	}
+	if s := SliceToSet[int](nil); s.Len() != 0 {
+		t.Errorf("expected empty set for nil input, got %v", s.Slice())
+	}
+}
+
+func TestUnique(t *testing.T) {
+	src := []string{"b", "a", "b", "c", "a", "b"}
+	got := Unique(src)
+	if !slices.Equal(got, []string{"b", "a", "c"}) {
+		t.Errorf("expected first-seen order without duplicates, got %v", got)
+	}
+	if !slices.Equal(src, []string{"b", "a", "b", "c", "a", "b"}) {
+		t.Errorf("source slice should not be modified, got %v", src)
+	}
+	if got := Unique([]int{1, 2, 3}); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected unchanged slice without duplicates, got %v", got)
+	}
+}
+
+func TestUnique_Empty(t *testing.T) {
+	if got := Unique[int](nil); got == nil || len(got) != 0 {
+		t.Errorf("expected non-nil empty slice for nil input, got %v", got)
+	}
+	if got := Unique([]int{}); got == nil || len(got) != 0 {
+		t.Errorf("expected non-nil empty slice for empty input, got %v", got)
+	}
+}
@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"context"
+	"sync"
+)
+
+// AwaitAll 阻塞直到调用时组内已注册的所有资源都已被初始化（通过 Get、WarmupAll 等任意路径）。
+//
+// AwaitAll 本身不会触发资源的创建，适用于测试中注册资源后等待后台预热完成的场景。
+// 等待期间被注销的资源不再等待；等待开始后新注册的资源不在等待范围内。
+//
+// 返回值:
+//   - nil: 所有资源均已就绪（组内没有资源时立即返回）
+//   - error: 组不存在时返回 ErrGroupNotFound；
+//     等待开始后某个资源初始化失败时返回 ErrOpenResourceFailed（包装了 Opener 的错误）；
+//     ctx 被取消或超时时返回 ctx 的错误
+//
+// 示例:
+//
+//	go mgr.WarmupAll(context.Background(), 4)
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+//	defer cancel()
+//	if err := group.AwaitAll(ctx); err != nil {
+//	    t.Fatal(err)
+//	}
+func (g *group[C, T]) AwaitAll(ctx context.Context) error {
+	return g.awaitAll(ctx, nil)
+}
+
+// awaitAll 实现 AwaitAll，只等待 match 返回 true 的资源；match 为 nil 时等待全部资源。
+//
+// 等待完全由通知驱动：资源就绪通过就绪状态回调唤醒，初始化失败和资源移除通过 awaitSubs 唤醒，
+// 订阅在读锁内完成，不会错过订阅之后发生的变化。
+func (g *group[C, T]) awaitAll(ctx context.Context, match func(name string) bool) error {
+	var (
+		mu          sync.Mutex
+		failed      error
+		wake        = make(chan struct{}, 1)
+		unsubscribe = make(map[string]func())
+	)
+	notify := func() {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+	defer func() {
+		for _, unsub := range unsubscribe {
+			unsub()
+		}
+	}()
+
+	g.m.mu.RLock()
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		g.m.mu.RUnlock()
+		return NewErrGroupNotFound(g.name)
+	}
+	pending := make(map[string]*connection[C, T], len(groupMap))
+	for name, conn := range groupMap {
+		if (match == nil || match(name)) && !conn.ready {
+			pending[name] = conn
+		}
+	}
+	for name := range pending {
+		name := name
+		unsubReady := g.m.readySubs.add(g.name, name, func(ready bool) {
+			if ready {
+				notify()
+			}
+		})
+		unsubAwait := g.m.awaitSubs.add(g.name, name, func(err error) {
+			if err != nil {
+				mu.Lock()
+				if failed == nil {
+					failed = NewErrOpenResourceFailed(g.name, name, err)
+				}
+				mu.Unlock()
+			}
+			notify()
+		})
+		unsubscribe[name] = func() {
+			unsubReady()
+			unsubAwait()
+		}
+	}
+	g.m.mu.RUnlock()
+
+	for {
+		mu.Lock()
+		err := failed
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+		for _, name := range g.prunePending(pending) {
+			unsubscribe[name]()
+			delete(unsubscribe, name)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// prunePending 从 pending 中移除已就绪或已被注销的资源，返回被移除的资源名。
+func (g *group[C, T]) prunePending(pending map[string]*connection[C, T]) []string {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	var pruned []string
+	groupMap := g.m.groups[g.name]
+	for name, conn := range pending {
+		if current, ok := groupMap[name]; !ok || current != conn || conn.ready {
+			delete(pending, name)
+			pruned = append(pruned, name)
+		}
+	}
+	return pruned
+}
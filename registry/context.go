@@ -0,0 +1,58 @@
+package registry
+
+import "context"
+
+// ResourceKey 是管理器在调用 Opener 和 Closer 前注入资源标识所用的 context key，
+// 对应的值类型为 ResourceIdentity。
+//
+// 一般通过 GroupFromContext、NameFromContext 读取即可；
+// 需要一次取出完整标识，或在测试中手动构造 ctx 时，可直接使用该 key。
+//
+// 示例:
+//
+//	id, ok := ctx.Value(registry.ResourceKey{}).(registry.ResourceIdentity)
+//	ctx = context.WithValue(ctx, registry.ResourceKey{}, registry.ResourceIdentity{Group: "mysql", Name: "main"})
+type ResourceKey struct{}
+
+// ResourceIdentity 是以 ResourceKey 注入到 ctx 中的资源标识。
+type ResourceIdentity struct {
+	Group string // Group 是资源所属的组名
+	Name  string // Name 是资源名称
+}
+
+// withResource 返回携带组名和资源名的 ctx，供 Opener 和 Closer 通过 GroupFromContext、NameFromContext 读取。
+func withResource(ctx context.Context, groupName, name string) context.Context {
+	return context.WithValue(ctx, ResourceKey{}, ResourceIdentity{Group: groupName, Name: name})
+}
+
+// GroupFromContext 返回当前正在创建或关闭的资源所属的组名。
+//
+// 管理器在调用 Opener 和 Closer 前会将组名和资源名注入 ctx，
+// 便于在其中创建链路追踪 span 或带标识的日志，而无需修改函数签名。
+//
+// 返回值:
+//   - string: 组名
+//   - bool: ctx 中是否携带资源标识；在 Opener、Closer 之外调用时为 false
+//
+// 示例:
+//
+//	opener := func(ctx context.Context, cfg DBConfig) (*sql.DB, error) {
+//	    group, _ := registry.GroupFromContext(ctx)
+//	    name, _ := registry.NameFromContext(ctx)
+//	    log.Printf("opening %s/%s", group, name)
+//	    return sql.Open("mysql", cfg.DSN)
+//	}
+func GroupFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ResourceKey{}).(ResourceIdentity)
+	return id.Group, ok
+}
+
+// NameFromContext 返回当前正在创建或关闭的资源名称。
+//
+// 返回值:
+//   - string: 资源名
+//   - bool: ctx 中是否携带资源标识；在 Opener、Closer 之外调用时为 false
+func NameFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ResourceKey{}).(ResourceIdentity)
+	return id.Name, ok
+}
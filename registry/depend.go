@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+)
+
+// DependentOpener 是依赖其他资源的打开器函数类型，通过 WithDependentOpener 设置。
+//
+// 与 Opener 相比多了 deps 参数，包含 GetWithDeps 中声明的依赖资源实例，key 为资源名。
+// 适用于双写迁移等需要在已有资源之上构建新资源的场景。
+//
+// 示例:
+//
+//	opener := func(ctx context.Context, cfg DBConfig, deps map[string]*DB) (*DB, error) {
+//	    return NewTeeDB(deps["old"], deps["new"]), nil
+//	}
+type DependentOpener[C any, T any] func(ctx context.Context, cfg C, deps map[string]T) (T, error)
+
+// GetWithDeps 先确保依赖资源均已初始化，再创建并返回指定资源。
+//
+// deps 按顺序通过 Get 获取；任意依赖获取失败时立即返回该错误，不会调用 opener 创建目标资源。
+// 目标资源未初始化时，通过 WithDependentOpener 设置的 DependentOpener 创建，
+// 依赖资源实例以 map 形式传入；未设置 DependentOpener 时退回使用普通 Opener。
+// 目标资源已初始化时直接返回，不会重新创建。
+//
+// 依赖关系由调用方保证无环，依赖资源本身通过普通 Opener 创建。
+//
+// 示例:
+//
+//	db, err := group.GetWithDeps(ctx, "tee", "old", "new")
+func (g *group[C, T]) GetWithDeps(ctx context.Context, name string, deps ...string) (T, error) {
+	return g.getWithDeps(ctx, name, deps, nil)
+}
+
+// getWithDeps 实现 GetWithDeps，deps 为组内的完整资源名。
+//
+// key 非 nil 时用于将依赖的完整资源名转换为传给 DependentOpener 的 map key，
+// 供带前缀的组视图去掉前缀；为 nil 时直接使用完整资源名。
+func (g *group[C, T]) getWithDeps(ctx context.Context, name string, deps []string, key func(name string) string) (T, error) {
+	var zero T
+	vals := make(map[string]T, len(deps))
+	for _, dep := range deps {
+		val, err := g.Get(ctx, dep)
+		if err != nil {
+			return zero, fmt.Errorf("dependency %q of resource %q in group %q: %w", dep, name, g.name, err)
+		}
+		if key != nil {
+			vals[key(dep)] = val
+		} else {
+			vals[dep] = val
+		}
+	}
+
+	if g.m.depOpener == nil {
+		return g.Get(ctx, name)
+	}
+	opener := g.m.wrapOpener(func(ctx context.Context, cfg C) (T, error) {
+		return g.m.depOpener(ctx, cfg, vals)
+	})
+	return g.get(ctx, name, opener)
+}
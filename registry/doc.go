@@ -44,6 +44,9 @@ Opener 是一个函数类型，定义了如何根据配置创建资源：
   - ctx: 上下文，用于超时控制和取消
   - cfg: 资源配置
 
+调用 Opener 和 Closer 时，ctx 中携带了当前资源的组名和资源名，
+可通过 GroupFromContext 和 NameFromContext 读取。
+
 ## Closer（关闭器）
 
 Closer 是一个函数类型，定义了如何关闭/销毁资源：
@@ -119,13 +122,36 @@ Closer 是一个函数类型，定义了如何关闭/销毁资源：
 
 	errs := mgr.Close(ctx)
 
+# 可选配置
+
+NewManager、New 和 NewGroupWithManager 均支持传入可选配置项（Option）：
+
+  - WithStrictGroups: 组被关闭后 Register 不再自动重建组，而是返回 ErrGroupNotFound
+  - WithPoolSize: 为指定资源配置 Acquire 使用的实例池大小
+  - WithConfigValidator: 注册时校验配置，校验失败返回 ErrInvalidConfig
+  - WithOpenerMiddleware: 为 Opener 添加中间件，先传入的位于最外层
+  - WithDependentOpener: 设置 GetWithDeps 使用的依赖感知打开器
+  - WithCloseOrder: 设置关闭组内资源时的顺序
+  - WithRecoverOpenerPanic: 将 Opener 中的 panic 转换为 ErrOpenerPanicked 错误
+  - WithRecoverCloserPanic: 将 Closer 中的 panic 转换为 ErrCloserPanicked 错误，关闭流程继续进行
+  - WithEventLog: 记录最近的注册表操作事件，通过 RecentEvents 查看
+
+示例：
+
+	mgr := registry.NewManager(opener, closer,
+	    registry.WithStrictGroups[DBConfig, *sql.DB](),
+	    registry.WithConfigValidator[DBConfig, *sql.DB](validateDBConfig),
+	)
+
 # 错误处理
 
 包中定义了以下错误类型：
 
   - ErrGroupNotFound: 指定的组不存在
   - ErrResourceNotFound: 指定的资源不存在
+  - ErrNoResources: 组内没有可供选择的候选资源（GetByKey、PingAny、GetFirstAvailable）
   - ErrCloseResourceFailed: 关闭资源时发生错误
+  - ErrFrozen: 管理器已冻结，不允许注册或注销资源
 
 可以使用 errors.Is 进行错误类型判断。
 
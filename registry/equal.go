@@ -0,0 +1,36 @@
+package registry
+
+// EqualConfigs 判断两个组注册的资源名及其配置是否完全一致，忽略资源的初始化状态和实例值。
+//
+// 主要用于测试中断言注册表状态。由于方法不能声明额外的类型约束，该函数以包级函数的形式提供。
+// 两个组各自的配置在一次读锁内快照，不存在的组视为空组。
+//
+// 示例:
+//
+//	if !registry.EqualConfigs(want, got) {
+//	    t.Errorf("unexpected registrations: %v", got.Configs())
+//	}
+func EqualConfigs[C comparable, T any](a, b Group[C, T]) bool {
+	return EqualConfigsFunc(a, b, func(x, y C) bool { return x == y })
+}
+
+// EqualConfigsFunc 与 EqualConfigs 相同，但使用 eq 比较配置，适用于不可比较的配置类型 C。
+//
+// 示例:
+//
+//	equal := registry.EqualConfigsFunc(a, b, func(x, y DBConfig) bool {
+//	    return reflect.DeepEqual(x, y)
+//	})
+func EqualConfigsFunc[C any, T any](a, b Group[C, T], eq func(x, y C) bool) bool {
+	ca, cb := a.Configs(), b.Configs()
+	if len(ca) != len(cb) {
+		return false
+	}
+	for name, x := range ca {
+		y, ok := cb[name]
+		if !ok || !eq(x, y) {
+			return false
+		}
+	}
+	return true
+}
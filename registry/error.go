@@ -22,14 +22,51 @@ var (
 	// 当调用 Group.Get 或 Group.Unregister 时，如果指定的资源未被注册，将返回此错误。
 	ErrResourceNotFound = errors.New("bizutil.registry: resource not found")
 
+	// ErrResourceExists 表示同名资源已在组中注册。
+	// 当调用 Group.CanRegister 检查已被占用的名称时，将返回此错误。
+	ErrResourceExists = errors.New("bizutil.registry: resource already exists")
+
+	// ErrAmbiguousResource 表示同名资源注册在多个组中，无法确定要使用哪一个。
+	// 当调用 Manager.FindResource 时，如果名称在多个组中存在，将返回此错误。
+	ErrAmbiguousResource = errors.New("bizutil.registry: ambiguous resource")
+
+	// ErrNoResources 表示组内没有可供选择的候选资源。
+	// 当调用 GetByKey、PingAny 时组内没有资源，或调用 GetFirstAvailable 时未传入任何名称，将返回此错误。
+	ErrNoResources = errors.New("bizutil.registry: no resources available")
+
 	// ErrCloseResourceFailed 表示关闭资源时发生错误。
 	// 当 Closer 函数返回错误时，将返回此错误。
 	ErrCloseResourceFailed = errors.New("bizutil.registry: close resource failed")
 
+	// ErrCloseInterrupted 表示关闭过程因 ctx 取消或超时而提前终止。
+	// 返回的错误同时包装了 ctx.Err()，可通过 errors.Is 判断 context.Canceled 或 context.DeadlineExceeded。
+	ErrCloseInterrupted = errors.New("bizutil.registry: close interrupted")
+
+	// ErrInvalidConfig 表示资源配置未通过 WithConfigValidator 设置的校验。
+	ErrInvalidConfig = errors.New("bizutil.registry: invalid config")
+
+	// ErrOpenResourceFailed 表示 Opener 创建资源时返回了错误。
+	// 返回的错误同时包装了 Opener 的原始错误，可通过 errors.Is 判断。
+	ErrOpenResourceFailed = errors.New("bizutil.registry: open resource failed")
+
+	// ErrFrozen 表示管理器已通过 Freeze 冻结，不允许再注册或注销资源。
+	ErrFrozen = errors.New("bizutil.registry: registry frozen")
+
+	// ErrOpenerPanicked 表示 Opener 在创建资源时发生了 panic。
+	// 仅在启用 WithRecoverOpenerPanic 时返回，错误信息包含 panic 的值和堆栈。
+	ErrOpenerPanicked = errors.New("bizutil.registry: opener panicked")
+
+	// ErrCloserPanicked 表示 Closer 在关闭资源时发生了 panic。
+	// 仅在启用 WithRecoverCloserPanic 时返回，错误信息包含 panic 的值和堆栈。
+	ErrCloserPanicked = errors.New("bizutil.registry: closer panicked")
+
 	// ErrPingResourceFailed
 	ErrPingResourceFailed = errors.New("bizutil.registry: ping resource failed")
 )
 
+// errPoolClosed 表示资源池在等待期间已被关闭，仅在包内部使用。
+var errPoolClosed = errors.New("bizutil.registry: pool closed")
+
 // NewErrGroupNotFound 创建一个包含组名信息的组未找到错误。
 //
 // 返回的错误可以通过 errors.Is(err, ErrGroupNotFound) 进行判断。
@@ -44,6 +81,27 @@ func NewErrResourceNotFound(groupName, resourceName string) error {
 	return fmt.Errorf("resource %q not found from group %q: %w", resourceName, groupName, ErrResourceNotFound)
 }
 
+// NewErrNoResources 创建一个包含组名信息的无候选资源错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrNoResources) 进行判断。
+func NewErrNoResources(groupName string) error {
+	return fmt.Errorf("no resources available in group %q: %w", groupName, ErrNoResources)
+}
+
+// NewErrResourceExists 创建一个包含组名和资源名信息的资源已存在错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrResourceExists) 进行判断。
+func NewErrResourceExists(groupName, resourceName string) error {
+	return fmt.Errorf("resource %q already exists in group %q: %w", resourceName, groupName, ErrResourceExists)
+}
+
+// NewErrAmbiguousResource 创建一个包含资源名和所在组名列表的资源歧义错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrAmbiguousResource) 进行判断。
+func NewErrAmbiguousResource(resourceName string, groupNames []string) error {
+	return fmt.Errorf("resource %q found in groups %q: %w", resourceName, groupNames, ErrAmbiguousResource)
+}
+
 // NewErrCloseResourceFailed 创建一个包含组名、资源名和原始错误的关闭失败错误。
 //
 // 返回的错误可以通过 errors.Is(err, ErrCloseResourceFailed) 进行判断，
@@ -52,6 +110,51 @@ func NewErrCloseResourceFailed(groupName, resourceName string, err error) error
 	return fmt.Errorf("close resource %q in group %q failed: %w: %w", resourceName, groupName, ErrCloseResourceFailed, err)
 }
 
+// NewErrCloseInterrupted 创建一个包含组名和 ctx 错误的关闭中断错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrCloseInterrupted) 进行判断，
+// 同时也可以通过 errors.Is 判断 ctx 的错误。
+func NewErrCloseInterrupted(groupName string, err error) error {
+	return fmt.Errorf("close group %q interrupted: %w: %w", groupName, ErrCloseInterrupted, err)
+}
+
+// NewErrInvalidConfig 创建一个包含组名、资源名和校验错误的配置无效错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrInvalidConfig) 进行判断，
+// 同时也可以通过 errors.Is 判断校验函数返回的原始错误。
+func NewErrInvalidConfig(groupName, resourceName string, err error) error {
+	return fmt.Errorf("invalid config for resource %q in group %q: %w: %w", resourceName, groupName, ErrInvalidConfig, err)
+}
+
+// NewErrOpenResourceFailed 创建一个包含组名、资源名和原始错误的创建失败错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrOpenResourceFailed) 进行判断，
+// 同时也可以通过 errors.Is 判断 Opener 返回的原始错误。
+func NewErrOpenResourceFailed(groupName, resourceName string, err error) error {
+	return fmt.Errorf("open resource %q in group %q failed: %w: %w", resourceName, groupName, ErrOpenResourceFailed, err)
+}
+
+// NewErrFrozen 创建一个包含组名信息的冻结错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrFrozen) 进行判断。
+func NewErrFrozen(groupName string) error {
+	return fmt.Errorf("cannot modify group %q: %w", groupName, ErrFrozen)
+}
+
+// NewErrOpenerPanicked 创建一个包含组名、资源名、panic 值和堆栈信息的 Opener panic 错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrOpenerPanicked) 进行判断。
+func NewErrOpenerPanicked(groupName, resourceName string, recovered any, stack []byte) error {
+	return fmt.Errorf("opener for resource %q in group %q panicked: %v: %w\n%s", resourceName, groupName, recovered, ErrOpenerPanicked, stack)
+}
+
+// NewErrCloserPanicked 创建一个包含组名、资源名、panic 值和堆栈信息的 Closer panic 错误。
+//
+// 返回的错误可以通过 errors.Is(err, ErrCloserPanicked) 进行判断。
+func NewErrCloserPanicked(groupName, resourceName string, recovered any, stack []byte) error {
+	return fmt.Errorf("closer for resource %q in group %q panicked: %v: %w\n%s", resourceName, groupName, recovered, ErrCloserPanicked, stack)
+}
+
 func NewErrPingResourceFailed(groupName, resourceName string, err error) error {
 	return fmt.Errorf("ping resource %q in group %q failed: %w", resourceName, groupName, ErrPingResourceFailed)
 }
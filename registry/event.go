@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"sync"
+	"time"
+)
+
+// EventKind 表示注册表操作事件的类型。
+type EventKind string
+
+// 事件类型常量。
+const (
+	EventRegister   EventKind = "register"    // EventRegister 表示资源被注册
+	EventOpen       EventKind = "open"        // EventOpen 表示 Opener 成功创建了资源实例
+	EventOpenFailed EventKind = "open_failed" // EventOpenFailed 表示 Opener 返回了错误
+	EventUnregister EventKind = "unregister"  // EventUnregister 表示资源被注销
+	EventClose      EventKind = "close"       // EventClose 表示已初始化的资源实例被关闭
+)
+
+// Event 是注册表操作事件，由 Manager.RecentEvents 返回。
+type Event struct {
+	Time  time.Time // Time 是事件发生的时间
+	Kind  EventKind // Kind 是事件类型
+	Group string    // Group 是资源所属的组名
+	Name  string    // Name 是资源名
+	Err   error     // Err 是 EventOpenFailed 的 Opener 错误或 EventClose 的 Closer 错误，其他情况为 nil
+}
+
+// eventLog 是固定容量的事件环形缓冲区，超出容量时丢弃最旧的事件。
+type eventLog struct {
+	mu     sync.Mutex
+	events []Event // events 是环形缓冲区，长度即容量
+	next   int     // next 是下一个写入位置
+	full   bool    // full 表示缓冲区是否已写满一轮
+}
+
+// newEventLog 创建指定容量的事件日志，capacity 小于 1 时返回 nil（不记录事件）。
+func newEventLog(capacity int) *eventLog {
+	if capacity < 1 {
+		return nil
+	}
+	return &eventLog{events: make([]Event, capacity)}
+}
+
+// add 追加一条事件，缓冲区已满时覆盖最旧的事件。
+func (l *eventLog) add(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events[l.next] = e
+	l.next++
+	if l.next == len(l.events) {
+		l.next = 0
+		l.full = true
+	}
+}
+
+// snapshot 按从旧到新的顺序返回缓冲区中的事件副本。
+func (l *eventLog) snapshot() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.full {
+		return append([]Event(nil), l.events[:l.next]...)
+	}
+	r := make([]Event, 0, len(l.events))
+	r = append(r, l.events[l.next:]...)
+	return append(r, l.events[:l.next]...)
+}
+
+// recordEvent 在启用 WithEventLog 时记录一条事件。
+func (m *manager[C, T]) recordEvent(kind EventKind, groupName, name string, err error) {
+	if m.events == nil {
+		return
+	}
+	m.events.add(Event{Time: m.clock(), Kind: kind, Group: groupName, Name: name, Err: err})
+}
+
+// RecentEvents 按从旧到新的顺序返回最近记录的注册表操作事件。
+//
+// 仅在通过 WithEventLog 启用事件日志时记录，未启用时返回空切片。
+// 记录的操作包括：注册（EventRegister）、Opener 创建成功或失败（EventOpen、EventOpenFailed）、
+// 注销（EventUnregister）以及已初始化资源的关闭（EventClose）。
+// 返回的切片是副本，修改它不会影响事件日志。
+//
+// 示例:
+//
+//	for _, e := range mgr.RecentEvents() {
+//	    log.Printf("%s %s %s/%s err=%v", e.Time.Format(time.RFC3339), e.Kind, e.Group, e.Name, e.Err)
+//	}
+func (m *manager[C, T]) RecentEvents() []Event {
+	if m.events == nil {
+		return []Event{}
+	}
+	return m.events.snapshot()
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"context"
+	"errors"
+)
+
+// GetFirstAvailable 按顺序尝试获取资源，返回第一个成功初始化的资源及其名称。
+//
+// 适用于"主库不可用时依次降级到备库"的场景。未注册的名称会被跳过而不会中止尝试。
+// 如果所有名称都失败，返回通过 errors.Join 合并的全部错误（包括未注册名称对应的 ErrResourceNotFound），
+// 可通过 errors.Is 判断其中任意一个错误；未传入任何名称时返回 ErrNoResources。
+//
+// 示例:
+//
+//	name, db, err := group.GetFirstAvailable(ctx, "primary", "secondary", "tertiary")
+func (g *group[C, T]) GetFirstAvailable(ctx context.Context, names ...string) (string, T, error) {
+	var zero T
+	var errs []error
+	for _, name := range names {
+		val, err := g.Get(ctx, name)
+		if err == nil {
+			return name, val, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		errs = append(errs, NewErrNoResources(g.name))
+	}
+	return "", zero, errors.Join(errs...)
+}
+
+// PingAny 依次 Ping 组内的资源（顺序不确定），只要有一个成功即返回 nil。
+//
+// 适用于快速存活检查：只关心组内是否至少有一个资源可达，而不必 Ping 全部资源。
+// ctx 被取消后停止尝试，并将 ctx 的错误一并返回。
+//
+// 返回值:
+//   - nil: 至少一个资源 Ping 成功
+//   - error: 组不存在时返回 ErrGroupNotFound；组内没有资源时返回 ErrNoResources；
+//     全部失败时返回通过 errors.Join 合并的全部错误
+//
+// 示例:
+//
+//	if err := group.PingAny(ctx); err != nil {
+//	    // 组内没有任何可用资源
+//	}
+func (g *group[C, T]) PingAny(ctx context.Context) error {
+	return g.pingAny(ctx, nil)
+}
+
+// pingAny 实现 PingAny，只 Ping match 返回 true 的资源；match 为 nil 时考虑全部资源。
+func (g *group[C, T]) pingAny(ctx context.Context, match func(name string) bool) error {
+	g.m.mu.RLock()
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		g.m.mu.RUnlock()
+		return NewErrGroupNotFound(g.name)
+	}
+	names := make([]string, 0, len(groupMap))
+	for name := range groupMap {
+		if match == nil || match(name) {
+			names = append(names, name)
+		}
+	}
+	g.m.mu.RUnlock()
+
+	if len(names) == 0 {
+		return NewErrNoResources(g.name)
+	}
+
+	var errs []error
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		err := g.Ping(ctx, name)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,48 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// FindResource 在所有组中查找指定名称的资源，初始化并返回资源及其所在的组名。
+//
+// 适用于资源名在整个管理器内唯一、调用方不关心其所在组的场景。
+// 查找在读锁内完成，随后通过该组的 Get 获取资源（按需惰性初始化）。
+//
+// 返回值:
+//   - groupName: 资源所在的组名
+//   - val: 资源实例
+//   - err: 没有任何组注册该名称时返回 ErrResourceNotFound；
+//     多个组注册了该名称时返回 ErrAmbiguousResource；初始化失败时返回 Get 的错误
+//
+// 示例:
+//
+//	groupName, db, err := mgr.FindResource(ctx, "orders_master")
+func (m *manager[C, T]) FindResource(ctx context.Context, name string) (groupName string, val T, err error) {
+	m.mu.RLock()
+	var found []string
+	for gn, groupMap := range m.groups {
+		if _, ok := groupMap[name]; ok {
+			found = append(found, gn)
+		}
+	}
+	m.mu.RUnlock()
+
+	switch len(found) {
+	case 0:
+		return "", val, fmt.Errorf("resource %q not found in any group: %w", name, ErrResourceNotFound)
+	case 1:
+	default:
+		sort.Strings(found)
+		return "", val, NewErrAmbiguousResource(name, found)
+	}
+
+	g := &group[C, T]{name: found[0], m: m}
+	val, err = g.Get(ctx, name)
+	if err != nil {
+		return "", val, err
+	}
+	return found[0], val, nil
+}
@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// readyResource 是 ForEachConcurrent 遍历的单个已初始化资源快照。
+type readyResource[T any] struct {
+	name string
+	val  T
+}
+
+// ForEachConcurrent 并发地对组内所有已初始化的资源执行 fn，同时运行的 fn 不超过 concurrency 个。
+//
+// 资源集合在调用时于读锁内快照，fn 在锁外执行，可以安全地调用当前组的方法。
+// 任意 fn 返回错误后，传给其余 fn 的 ctx 会被取消，且不再派发新的资源，最终返回第一个错误。
+// 未初始化的资源会被跳过；concurrency 小于 1 时按 1 处理。
+//
+// 返回值:
+//   - nil: 所有 fn 均执行成功
+//   - error: 组不存在时返回 ErrGroupNotFound；否则返回第一个 fn 错误，
+//     若没有 fn 出错但 ctx 被取消导致未全部执行，返回 ctx 的错误
+//
+// 示例:
+//
+//	err := group.ForEachConcurrent(ctx, 4, func(ctx context.Context, name string, db *sql.DB) error {
+//	    return db.PingContext(ctx)
+//	})
+func (g *group[C, T]) ForEachConcurrent(ctx context.Context, concurrency int, fn func(ctx context.Context, name string, val T) error) error {
+	concurrency = max(concurrency, 1)
+
+	g.m.mu.RLock()
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		g.m.mu.RUnlock()
+		return NewErrGroupNotFound(g.name)
+	}
+	var targets []readyResource[T]
+	for name, conn := range groupMap {
+		if conn.ready {
+			targets = append(targets, readyResource[T]{name: name, val: conn.val})
+		}
+	}
+	g.m.mu.RUnlock()
+
+	sort.Slice(targets, func(i, j int) bool { return targets[i].name < targets[j].name })
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+		errOnce  sync.Once
+		firstErr error
+
+		interrupted bool
+	)
+
+	for _, target := range targets {
+		select {
+		case sem <- struct{}{}:
+		case <-runCtx.Done():
+		}
+		if runCtx.Err() != nil {
+			interrupted = true
+			break
+		}
+
+		wg.Add(1)
+		go func(target readyResource[T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(runCtx, target.name, target.val); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if interrupted {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// CollectReady 对组内所有已初始化的资源调用 f，并以资源名为 key 收集结果。
+//
+// 由于方法不能声明额外的类型参数，该函数以包级函数的形式提供。
+// 资源集合在调用时于读锁内快照，未初始化的资源会被跳过，f 在锁外按资源名升序依次执行。
+// 组不存在或 ctx 被取消时返回已收集的结果。
+//
+// 示例:
+//
+//	stats := registry.CollectReady(ctx, group, func(name string, db *sql.DB) sql.DBStats {
+//	    return db.Stats()
+//	})
+func CollectReady[C any, T any, R any](ctx context.Context, g Group[C, T], f func(name string, val T) R) map[string]R {
+	var mu sync.Mutex
+	results := make(map[string]R)
+	_ = g.ForEachConcurrent(ctx, 1, func(_ context.Context, name string, val T) error {
+		r := f(name, val)
+		// Group 的其他实现不一定遵守并发数，写入结果时加锁保护
+		mu.Lock()
+		results[name] = r
+		mu.Unlock()
+		return nil
+	})
+	return results
+}
@@ -0,0 +1,39 @@
+package registry
+
+// Freeze 冻结管理器，此后不允许再注册或注销资源。
+//
+// 适用于启动完成后希望注册表保持不变、以便尽早发现意外动态修改的服务。
+// 冻结保护的是注册内容，即存在哪些组和资源以及它们的配置；资源实例的生命周期操作和关闭流程不受限制。
+//
+// 冻结后以下修改注册内容的操作返回 ErrFrozen：
+//   - Register、RegisterTagged、RegisterWithTTL
+//   - ComputeIfAbsent（名称不存在时）
+//   - Replace
+//   - Unregister、UnregisterWhere、UnregisterEverywhere
+//   - Reset
+//   - AddGroupE（AddGroup 不再创建新组）
+//
+// 以下操作不受影响：
+//   - Get、List、Config 等读取操作
+//   - GetFresh、CloseResources 等只重建或关闭实例、不改变注册内容的操作
+//   - Group.Close、Manager.Close、ClosePerGroup 等关闭流程
+//
+// Manager.Close 或 ClosePerGroup 关闭全部组后会解除冻结。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer)
+//	// ... 启动阶段注册所有资源 ...
+//	mgr.Freeze()
+func (m *manager[C, T]) Freeze() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.frozen = true
+}
+
+// IsFrozen 返回管理器当前是否处于冻结状态。
+func (m *manager[C, T]) IsFrozen() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.frozen
+}
@@ -0,0 +1,50 @@
+package registry
+
+import "sync/atomic"
+
+// groupGeneration 返回指定组的变更代数计数器，不存在时创建，仅在发生变更时调用。
+//
+// 计数器在组被关闭后仍然保留，同名组重建后代数继续递增而不会回退。
+func (m *manager[C, T]) groupGeneration(groupName string) *atomic.Uint64 {
+	if v, ok := m.groupGens.Load(groupName); ok {
+		return v.(*atomic.Uint64)
+	}
+	v, _ := m.groupGens.LoadOrStore(groupName, new(atomic.Uint64))
+	return v.(*atomic.Uint64)
+}
+
+// bumpGeneration 递增指定组及管理器的变更代数。
+func (m *manager[C, T]) bumpGeneration(groupName string) {
+	m.groupGeneration(groupName).Add(1)
+	m.generation.Add(1)
+}
+
+// Generation 返回管理器的变更代数，创建组以及任意组内发生注册、注销、重新创建（GetFresh）或关闭时递增。
+//
+// 只有资源的注册状态或就绪状态实际发生变化时才会递增：关闭从未初始化的资源不会改变代数，
+// GetFresh 只在关闭了旧实例或成功创建新实例时递增。
+// 读取不需要加锁，适合下游缓存以极低的开销判断注册表是否发生变化：
+// 代数与缓存时记录的值不同，说明缓存可能已过期。惰性初始化、Get、List 等只读操作不会改变代数。
+// 代数只保证单调递增，不保证每次变更恰好加 1。
+//
+// 示例:
+//
+//	if gen := mgr.Generation(); gen != cached.gen {
+//	    cached = rebuild(mgr)
+//	    cached.gen = gen
+//	}
+func (m *manager[C, T]) Generation() uint64 {
+	return m.generation.Load()
+}
+
+// Generation 返回组的变更代数，组被创建以及组内发生注册、注销、重新创建（GetFresh）或关闭时递增。
+//
+// 与 Manager.Generation 相同，读取不需要加锁，只保证单调递增；组被关闭后重建时代数继续递增。
+// 从未发生过变更的组（包括不存在的组）返回 0，读取不会为其创建计数器。
+func (g *group[C, T]) Generation() uint64 {
+	v, ok := g.m.groupGens.Load(g.name)
+	if !ok {
+		return 0
+	}
+	return v.(*atomic.Uint64).Load()
+}
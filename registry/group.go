@@ -1,6 +1,9 @@
 package registry
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Group 是资源组接口，用于管理一组相关的资源。
 //
@@ -19,16 +22,48 @@ type Group[C any, T any] interface {
 	// 可能返回的错误:
 	//   - ErrGroupNotFound: 组不存在
 	//   - ErrResourceNotFound: 资源未注册
-	//   - Opener 返回的错误: 资源创建失败
+	//   - ErrOpenResourceFailed: 资源创建失败，同时包装了 Opener 返回的原始错误
 	Get(ctx context.Context, name string) (T, error)
 
+	// GetFirstAvailable 按顺序尝试获取资源，返回第一个成功初始化的资源及其名称。
+	// 未注册的名称会被跳过；全部失败时返回通过 errors.Join 合并的错误。
+	GetFirstAvailable(ctx context.Context, names ...string) (name string, val T, err error)
+
+	// GetByKey 通过一致性哈希将 routingKey 映射到组内固定的资源并返回。
+	// 增删某个资源只会影响原本映射到该资源的键。
+	GetByKey(ctx context.Context, routingKey string) (name string, val T, err error)
+
+	// ForEachConcurrent 并发地对组内所有已初始化的资源执行 fn，同时运行的 fn 不超过 concurrency 个。
+	// 任意 fn 出错后取消其余执行，并返回第一个错误。
+	ForEachConcurrent(ctx context.Context, concurrency int, fn func(ctx context.Context, name string, val T) error) error
+
+	// GetWithDeps 先通过 Get 初始化 deps 中的依赖资源，再使用 WithDependentOpener 设置的打开器创建指定资源。
+	// 任意依赖获取失败时立即返回，不会创建目标资源。
+	GetWithDeps(ctx context.Context, name string, deps ...string) (T, error)
+
+	// GetFresh 关闭资源当前的共享实例，使用当前配置重新创建并返回新实例。
+	// 关闭旧实例的错误会被忽略，创建失败时返回 ErrOpenResourceFailed。
+	GetFresh(ctx context.Context, name string) (T, error)
+
+	// GetScoped 在 WithScope 创建的请求作用域内获取资源，同一作用域内重复调用返回同一个实例。
+	// ctx 不携带作用域时等价于 Get。
+	GetScoped(ctx context.Context, name string) (T, error)
+
 	// MustGet 根据名称获取资源。
 	// 如果获取失败，会触发 panic。
 	MustGet(ctx context.Context, name string) T
 
+	// GetOrZero 根据名称获取资源。
+	// 如果获取失败，返回零值，错误信息被丢弃。
+	GetOrZero(ctx context.Context, name string) T
+
 	Config(ctx context.Context, name string) (C, error)
 	MustConfig(ctx context.Context, name string) C
 
+	// Configs 返回组内所有已注册资源的配置快照，key 为资源名。
+	// 组不存在时返回空 map。
+	Configs() map[string]C
+
 	// Register 向组中注册一个新的资源配置。
 	//
 	// 注意：此方法只保存配置，不会立即创建资源。
@@ -36,26 +71,114 @@ type Group[C any, T any] interface {
 	//
 	// 返回值:
 	//   - isNew: true 表示新注册成功，false 表示资源名已存在（不会覆盖）
-	//   - err: 目前始终为 nil，保留用于将来扩展
+	//   - err: ctx 已取消或超时时返回 ctx.Err() 且不注册，
+	//     配置未通过 WithConfigValidator 校验时返回 ErrInvalidConfig，
+	//     启用 WithStrictGroups 且组不存在时返回 ErrGroupNotFound，
+	//     管理器已冻结时返回 ErrFrozen，否则为 nil
 	Register(ctx context.Context, name string, cfg C) (isNew bool, err error)
 
+	// CanRegister 检查 Register 当前是否会成功注册指定资源，但不修改任何状态。
+	// 不能注册时 reason 为 ErrInvalidConfig、ErrFrozen、ErrGroupNotFound 或 ErrResourceExists。
+	CanRegister(name string, cfg C) (ok bool, reason error)
+
+	// ComputeIfAbsent 返回指定资源；资源未注册时先通过 cfgFn 计算配置并注册，再惰性初始化。
+	// cfgFn 仅在资源不存在时调用，查找、注册和初始化在同一次加锁内完成。
+	ComputeIfAbsent(ctx context.Context, name string, cfgFn func() C) (T, error)
+
+	// RegisterTagged 向组中注册一个带标签的资源配置，其余行为与 Register 一致。
+	RegisterTagged(ctx context.Context, name string, cfg C, tags map[string]string) (isNew bool, err error)
+
+	// RegisterWithTTL 注册资源配置，并设置共享实例的最大存活时间。
+	// 实例创建后超过 ttl 时，下一次 Get 会关闭旧实例并重新创建；ttl 小于等于 0 时不过期。
+	RegisterWithTTL(ctx context.Context, name string, cfg C, ttl time.Duration) (isNew bool, err error)
+
+	// FindByTag 返回标签 key 的值等于 value 的所有资源名称。
+	FindByTag(key, value string) []string
+
+	// Tags 返回指定资源的标签副本。
+	// 如果资源不存在，返回 ErrResourceNotFound 错误。
+	Tags(name string) (map[string]string, error)
+
+	// OpenCount 返回为指定资源的共享实例实际调用 opener 的次数（包括失败的调用）。
+	// 组或资源不存在时返回 0。
+	OpenCount(name string) uint64
+
+	// LastError 返回指定资源最近一次初始化失败的错误及发生时间。
+	// 资源从未失败或之后已成功初始化时 ok 为 false。
+	LastError(name string) (err error, at time.Time, ok bool)
+
+	// Touch 将指定资源的最近访问时间更新为当前时间，不会获取或初始化资源，也没有回收效果。
+	// 如果资源不存在，返回 ErrResourceNotFound 错误。
+	Touch(name string) error
+
+	// LastAccess 返回指定资源最近一次通过 Get 成功获取或 Touch 的时间。
+	// 资源不存在或从未被访问时 ok 为 false。
+	LastAccess(name string) (at time.Time, ok bool)
+
+	// View 返回指定资源在同一次读锁内采集的只读快照，不会触发惰性初始化。
+	// 如果资源不存在，返回 ErrResourceNotFound 错误。
+	View(name string) (ResourceView[C, T], error)
+
 	// Unregister 从组中注销指定资源。
 	//
 	// 如果资源已初始化，会先调用 Closer 关闭资源。
 	// 如果资源不存在，返回 ErrResourceNotFound 错误。
 	Unregister(ctx context.Context, name string) error
 
+	// UnregisterWhere 注销组内所有满足 pred 的资源，已初始化的资源会先调用 Closer 关闭。
+	// 返回关闭过程中遇到的错误（包装为 ErrCloseResourceFailed）。
+	UnregisterWhere(ctx context.Context, pred func(name string, cfg C) bool) []error
+
 	// List 返回组内所有已注册的资源名称列表。
 	List() []string
 
+	// Select 返回组内配置满足 pred 的所有资源名称，pred 在读锁内执行。
+	Select(pred func(name string, cfg C) bool) []string
+
 	// Close 关闭组内所有已初始化的资源。
 	// 返回关闭过程中遇到的所有错误。
 	// 调用后，整个组将从管理器中移除。
+	// 若 ctx 在关闭过程中被取消，会提前停止并返回 ErrCloseInterrupted，组和未关闭的资源保持注册。
 	Close(ctx context.Context) []error
 
+	// CloseResources 关闭组内所有已初始化的资源，但保留组及资源配置。
+	// 资源被重置为未初始化状态，后续 Get 会重新创建。
+	CloseResources(ctx context.Context) []error
+
+	// Reset 关闭组内所有已初始化的资源并清空全部注册，但保留空组。
+	Reset(ctx context.Context) []error
+
+	// Acquire 从资源池中借出一个独占的资源实例。
+	//
+	// 池的大小通过 WithPoolSize 配置（默认 1），池满时阻塞直到有实例被归还或 ctx 结束。
+	// 返回的 release 函数用于归还实例，使用完毕后必须调用。
+	Acquire(ctx context.Context, name string) (val T, release func(), err error)
+
+	// OnReadyChange 注册一个回调，在指定资源变为就绪（首次初始化成功）
+	// 或不再就绪（被关闭、注销）时在锁外调用。
+	// 返回的函数用于注销该回调。
+	OnReadyChange(name string, cb func(ready bool)) (unsubscribe func())
+
 	// Ping 遍历组内所有已注册资源，尝试初始化以验证可用性。
 	//
 	// Ping 不会将资源保存到组中。
 	// 返回的 errors 列表包含所有无法初始化的资源及其错误。
 	Ping(ctx context.Context, name string) error
+
+	// PingAny 依次 Ping 组内资源，只要有一个成功即返回 nil；全部失败时返回合并的错误。
+	PingAny(ctx context.Context) error
+
+	// Replace 使用新配置创建资源实例，成功后原子地替换配置和共享实例，旧实例在后台关闭。
+	// 创建失败时旧实例保持不变。
+	Replace(ctx context.Context, name string, cfg C) (T, error)
+
+	// Generation 返回组的变更代数，注册、注销、重新创建或关闭资源时递增，读取无需加锁。
+	Generation() uint64
+
+	// AwaitAll 阻塞直到调用时组内已注册的所有资源都被初始化，本身不会触发资源创建。
+	// ctx 被取消或某个资源初始化失败时提前返回错误。
+	AwaitAll(ctx context.Context) error
+
+	// WithPrefix 返回以 prefix 为作用域的组视图，资源名自动加上或去掉 prefix。
+	WithPrefix(prefix string) Group[C, T]
 }
@@ -18,15 +18,73 @@ type Manager[C any, T any] interface {
 
 	// AddGroup 添加一个新的资源组。
 	// 返回值表示组是否已经存在：
-	//   - false: 组是新创建的
+	//   - false: 组是新创建的；管理器已冻结时组不会被创建，同样返回 false
 	//   - true: 组已经存在（不会重新创建）
+	// 需要区分冻结导致的创建失败时请使用 AddGroupE。
 	AddGroup(name string) bool
 
+	// AddGroupE 与 AddGroup 相同，但管理器已冻结且组不存在时返回 ErrFrozen，组不会被创建。
+	AddGroupE(name string) (existed bool, err error)
+
 	// ListGroupNames 返回所有已注册的组名列表。
 	ListGroupNames() []string
 
+	// ListGroupNamesPage 按组名升序分页返回组名，同时返回组的总数。
+	// offset 超出范围或 limit <= 0 时返回空切片。
+	ListGroupNamesPage(offset, limit int) (names []string, total int)
+
+	// GroupSummaries 返回所有组的概览信息（组名、资源总数、已初始化资源数），按组名升序排列。
+	// 所有数据在同一次读锁内采集，保证快照的一致性。
+	GroupSummaries() []GroupSummary
+
+	// FindResource 在所有组中查找指定名称的资源，初始化并返回资源及其所在的组名。
+	// 名称不存在时返回 ErrResourceNotFound，存在于多个组时返回 ErrAmbiguousResource。
+	FindResource(ctx context.Context, name string) (groupName string, val T, err error)
+
+	// UnregisterEverywhere 在所有组中注销指定名称的资源，已初始化的资源会先调用 Closer 关闭。
+	// 返回关闭失败的组及其错误，key 为组名；资源在某个组中不存在不视为错误。
+	UnregisterEverywhere(ctx context.Context, name string) map[string]error
+
+	// ManagerStats 返回整个管理器的统计快照，包括组数、资源数、已初始化资源数及 opener 调用次数。
+	ManagerStats() ManagerStats
+
+	// MarshalOverview 将所有组及资源的注册和就绪状态编码为 JSON，不包含配置。
+	MarshalOverview() ([]byte, error)
+
+	// MarshalOverviewWithConfigs 与 MarshalOverview 相同，但额外输出每个资源的配置，只应在可信环境中使用。
+	MarshalOverviewWithConfigs() ([]byte, error)
+
+	// Generation 返回管理器的变更代数，创建组或任意组发生注册、注销、重新创建或关闭时递增，读取无需加锁。
+	Generation() uint64
+
+	// RecentEvents 按从旧到新的顺序返回通过 WithEventLog 记录的最近操作事件。
+	RecentEvents() []Event
+
+	// WarmupAll 预先初始化所有尚未就绪的资源，同时进行的 opener 调用不超过 concurrency 个。
+	// 返回初始化失败的资源错误，外层 key 为组名，内层 key 为资源名。
+	WarmupAll(ctx context.Context, concurrency int) map[string]map[string]error
+
+	// Freeze 冻结管理器的注册内容，此后注册、替换和注销资源返回 ErrFrozen，AddGroup 不再创建新组，AddGroupE 返回 ErrFrozen。
+	// 读取、实例的重建与关闭以及 Close 等关闭流程不受影响，Close 成功完成后解除冻结。
+	Freeze()
+
+	// IsFrozen 返回管理器当前是否处于冻结状态。
+	IsFrozen() bool
+
 	// Close 关闭管理器中所有已初始化的资源。
 	// 返回关闭过程中遇到的所有错误。
 	// 调用后，管理器将被重置为空状态。
+	// 若 ctx 在关闭过程中被取消，会提前停止并返回 ErrCloseInterrupted，未关闭的资源保持注册。
 	Close(ctx context.Context) []error
+
+	// ClosePerGroup 尝试关闭所有组的资源，并按组名返回各组的关闭错误。
+	// 所有组都处理完毕后才会从管理器中移除。
+	ClosePerGroup(ctx context.Context) map[string][]error
+}
+
+// GroupSummary 是资源组的概览信息，由 Manager.GroupSummaries 返回。
+type GroupSummary struct {
+	Name  string // Name 是组名
+	Total int    // Total 是组内已注册的资源总数
+	Ready int    // Ready 是组内已完成初始化的资源数
 }
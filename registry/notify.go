@@ -0,0 +1,127 @@
+package registry
+
+import "sync"
+
+// readyChange 记录一次资源就绪状态的变化。
+type readyChange struct {
+	group string // group 是资源所在的组名
+	name  string // name 是资源名
+	ready bool   // ready 是变化后的就绪状态
+}
+
+// awaitChange 记录一次可能影响 AwaitAll 等待结果的变化：资源初始化失败或未就绪的资源被移除。
+type awaitChange struct {
+	group string // group 是资源所在的组名
+	name  string // name 是资源名
+	err   error  // err 是初始化失败的错误，资源被移除时为 nil
+}
+
+// subscribers 保存按组名和资源名注册的回调。
+//
+// 使用独立的锁保护，回调的注册与注销不需要获取 manager 的锁。
+type subscribers[A any] struct {
+	mu     sync.Mutex
+	nextID uint64
+	subs   map[string]map[string]map[uint64]func(A) // 组名 -> 资源名 -> 订阅 ID -> 回调
+}
+
+// add 注册一个回调，返回用于注销的函数。
+func (s *subscribers[A]) add(groupName, name string, cb func(A)) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subs == nil {
+		s.subs = make(map[string]map[string]map[uint64]func(A))
+	}
+	byName, ok := s.subs[groupName]
+	if !ok {
+		byName = make(map[string]map[uint64]func(A))
+		s.subs[groupName] = byName
+	}
+	cbs, ok := byName[name]
+	if !ok {
+		cbs = make(map[uint64]func(A))
+		byName[name] = cbs
+	}
+	s.nextID++
+	id := s.nextID
+	cbs[id] = cb
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.subs[groupName][name], id)
+			if len(s.subs[groupName][name]) == 0 {
+				delete(s.subs[groupName], name)
+			}
+			if len(s.subs[groupName]) == 0 {
+				delete(s.subs, groupName)
+			}
+		})
+	}
+}
+
+// callbacks 返回指定资源当前的全部回调副本。
+func (s *subscribers[A]) callbacks(groupName, name string) []func(A) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cbs := s.subs[groupName][name]
+	if len(cbs) == 0 {
+		return nil
+	}
+	r := make([]func(A), 0, len(cbs))
+	for _, cb := range cbs {
+		r = append(r, cb)
+	}
+	return r
+}
+
+// OnReadyChange 注册一个回调，在指定资源的就绪状态变化时调用。
+//
+// 以下情况会触发回调：
+//   - ready=true: 资源通过 Get 首次成功初始化
+//   - ready=false: 已初始化的资源因 Unregister、Group.Close 或 Manager.Close 被关闭
+//
+// 回调在释放 manager 的锁之后调用，因此可以在回调中安全地访问注册表。
+// 同一资源可以注册多个回调，调用顺序不保证。回调可以在资源注册之前注册。
+//
+// 返回值:
+//   - func(): 注销函数，调用后不再接收通知，多次调用只会生效一次
+func (g *group[C, T]) OnReadyChange(name string, cb func(ready bool)) func() {
+	return g.m.readySubs.add(g.name, name, cb)
+}
+
+// queueReadyChange 记录一次就绪状态变化，待 unlockAndNotify 释放写锁后通知订阅者。
+//
+// 调用方必须持有 manager 的写锁。
+func (m *manager[C, T]) queueReadyChange(groupName, name string, ready bool) {
+	m.pendingReady = append(m.pendingReady, readyChange{group: groupName, name: name, ready: ready})
+}
+
+// queueAwaitChange 记录一次初始化失败或未就绪资源的移除，待 unlockAndNotify 释放写锁后通知 AwaitAll。
+//
+// 调用方必须持有 manager 的写锁。
+func (m *manager[C, T]) queueAwaitChange(groupName, name string, err error) {
+	m.pendingAwait = append(m.pendingAwait, awaitChange{group: groupName, name: name, err: err})
+}
+
+// unlockAndNotify 释放 manager 的写锁，并在锁外通知持有写锁期间记录的就绪状态变化和 AwaitAll 关心的变化。
+func (m *manager[C, T]) unlockAndNotify() {
+	changes, awaits := m.pendingReady, m.pendingAwait
+	m.pendingReady, m.pendingAwait = nil, nil
+	m.mu.Unlock()
+
+	for _, c := range changes {
+		for _, cb := range m.readySubs.callbacks(c.group, c.name) {
+			cb(c.ready)
+		}
+	}
+	for _, c := range awaits {
+		for _, cb := range m.awaitSubs.callbacks(c.group, c.name) {
+			cb(c.err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package registry
+
+import "time"
+
+// Option 是创建资源管理器时使用的可选配置项。
+//
+// Option 可同时用于 NewManager 和 New，按传入顺序依次应用。
+//
+// 类型参数:
+//   - C: 配置类型
+//   - T: 资源类型
+type Option[C any, T any] func(m *manager[C, T])
+
+// WithStrictGroups 启用严格分组模式。
+//
+// 默认情况下，若组已被 Close 删除，Register 会自动重新创建该组，这可能掩盖"关闭后继续使用"的问题。
+// 启用严格模式后，Register 在组不存在时返回 ErrGroupNotFound，调用方必须先通过 AddGroup 显式创建组。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer, registry.WithStrictGroups[DBConfig, *sql.DB]())
+func WithStrictGroups[C any, T any]() Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.strictGroups = true
+	}
+}
+
+// WithConfigValidator 设置注册时使用的配置校验函数。
+//
+// 设置后，Register 会在保存配置前调用 validate，校验失败时返回包装为 ErrInvalidConfig 的错误，
+// 配置不会被保存。未设置时注册不会因配置内容而失败。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer, registry.WithConfigValidator[DBConfig, *sql.DB](func(cfg DBConfig) error {
+//	    if cfg.DSN == "" {
+//	        return errors.New("empty dsn")
+//	    }
+//	    return nil
+//	}))
+func WithConfigValidator[C any, T any](validate func(C) error) Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.validator = validate
+	}
+}
+
+// WithOpenerMiddleware 为 Opener 添加中间件，用于在不修改 Opener 的情况下附加链路追踪、超时、重试、日志等横切逻辑。
+//
+// 多个中间件按传入顺序组合，先传入的位于最外层、最先执行，与 HTTP 中间件的约定一致。
+// 所有创建资源的路径（Get、Acquire、WarmupAll、Ping）都会经过组合后的 Opener。
+//
+// 示例:
+//
+//	timeout := func(next registry.Opener[DBConfig, *sql.DB]) registry.Opener[DBConfig, *sql.DB] {
+//	    return func(ctx context.Context, cfg DBConfig) (*sql.DB, error) {
+//	        ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+//	        defer cancel()
+//	        return next(ctx, cfg)
+//	    }
+//	}
+//	mgr := registry.NewManager(opener, closer, registry.WithOpenerMiddleware(timeout))
+func WithOpenerMiddleware[C any, T any](mw func(next Opener[C, T]) Opener[C, T]) Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.middlewares = append(m.middlewares, mw)
+	}
+}
+
+// WithDependentOpener 设置 GetWithDeps 使用的依赖感知打开器。
+//
+// 只有通过 GetWithDeps 触发的初始化才会使用 open，其余路径仍使用普通 Opener。
+// WithOpenerMiddleware 添加的中间件同样作用于 open。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer, registry.WithDependentOpener(func(ctx context.Context, cfg DBConfig, deps map[string]*DB) (*DB, error) {
+//	    return NewTeeDB(deps["old"], deps["new"]), nil
+//	}))
+func WithDependentOpener[C any, T any](open DependentOpener[C, T]) Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.depOpener = open
+	}
+}
+
+// WithCloseOrder 设置关闭组内资源时的顺序，适用于资源之间存在依赖、需要按特定顺序关闭的场景。
+//
+// Manager.Close、Group.Close 和 Reset 会将组内资源名（按升序排列）传给 order，并按其返回的顺序依次调用 Closer。
+// order 返回结果中不存在的名称会被忽略，遗漏的名称按升序在最后关闭。order 在写锁内执行。
+// 未设置时关闭顺序不确定。
+//
+// 示例:
+//
+//	// 先关闭依赖数据库的事务管理器，最后关闭数据库
+//	rank := map[string]int{"txmanager": 0, "db": 1}
+//	mgr := registry.NewManager(opener, closer, registry.WithCloseOrder[Config, io.Closer](func(names []string) []string {
+//	    sort.SliceStable(names, func(i, j int) bool { return rank[names[i]] < rank[names[j]] })
+//	    return names
+//	}))
+func WithCloseOrder[C any, T any](order func(names []string) []string) Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.closeOrderFn = order
+	}
+}
+
+// WithRecoverOpenerPanic 启用 Opener panic 恢复。
+//
+// 默认情况下 Opener 中的 panic 会直接向上传播，可能导致整个服务崩溃。
+// 启用后，所有创建资源的路径（Get、Acquire、WarmupAll 等）都会恢复 Opener 的 panic，
+// 将其转换为 ErrOpenerPanicked 错误（包含 panic 的值和堆栈），资源保持未初始化状态，管理器可以继续使用。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer, registry.WithRecoverOpenerPanic[DBConfig, *sql.DB]())
+func WithRecoverOpenerPanic[C any, T any]() Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.recoverPanics = true
+	}
+}
+
+// WithRecoverCloserPanic 启用 Closer panic 恢复。
+//
+// Closer 在持有写锁时被调用，默认情况下其中的 panic 会直接向上传播，导致锁无法释放、后续调用全部死锁。
+// 启用后，每次调用 Closer 时都会恢复 panic，将其转换为 ErrCloserPanicked 错误，
+// 并按普通关闭失败处理（以 ErrCloseResourceFailed 包装返回），其余资源的关闭继续进行。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer, registry.WithRecoverCloserPanic[DBConfig, *sql.DB]())
+func WithRecoverCloserPanic[C any, T any]() Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.recoverCloser = true
+	}
+}
+
+// WithEventLog 启用事件日志，保留最近 capacity 条注册表操作事件，可通过 Manager.RecentEvents 查看。
+//
+// 事件保存在固定容量的环形缓冲区中，超出容量时丢弃最旧的事件，适用于审计和事后排查问题。
+// capacity 小于 1 时不启用事件日志。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer, registry.WithEventLog[DBConfig, *sql.DB](256))
+func WithEventLog[C any, T any](capacity int) Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.events = newEventLog(capacity)
+	}
+}
+
+// WithClock 设置管理器获取当前时间的函数，默认使用 time.Now。
+//
+// 管理器记录的所有时间都来自 now，包括 RegisterWithTTL 的过期判断、实例的创建时间、
+// LastAccess、LastError 的发生时间以及事件日志的时间，适用于在测试中控制时间流逝。
+//
+// 示例:
+//
+//	now := time.Now()
+//	mgr := registry.NewManager(opener, closer, registry.WithClock[DBConfig, *sql.DB](func() time.Time { return now }))
+func WithClock[C any, T any](now func() time.Time) Option[C, T] {
+	return func(m *manager[C, T]) {
+		m.now = now
+	}
+}
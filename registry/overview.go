@@ -0,0 +1,68 @@
+package registry
+
+import "encoding/json"
+
+// overview 是 MarshalOverview 输出的 JSON 结构。
+type overview struct {
+	Groups map[string]groupOverview `json:"groups"`
+}
+
+// groupOverview 是单个组的 JSON 概览。
+type groupOverview struct {
+	Resources map[string]resourceOverview `json:"resources"`
+}
+
+// resourceOverview 是单个资源的 JSON 概览，Config 仅在 MarshalOverviewWithConfigs 中输出。
+type resourceOverview struct {
+	Ready  bool `json:"ready"`
+	Config any  `json:"config,omitempty"`
+}
+
+// MarshalOverview 将管理器的注册状态编码为 JSON，适用于管理后台的状态接口。
+//
+// 输出结构为 {"groups": {"组名": {"resources": {"资源名": {"ready": true}}}}}，
+// 只包含注册信息和就绪状态，不包含资源实例。配置可能包含密码等敏感信息，默认不输出，
+// 需要时请使用 MarshalOverviewWithConfigs。所有数据在同一次读锁内采集。
+//
+// 示例:
+//
+//	http.HandleFunc("/debug/registry", func(w http.ResponseWriter, r *http.Request) {
+//	    data, err := mgr.MarshalOverview()
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusInternalServerError)
+//	        return
+//	    }
+//	    w.Header().Set("Content-Type", "application/json")
+//	    w.Write(data)
+//	})
+func (m *manager[C, T]) MarshalOverview() ([]byte, error) {
+	return m.marshalOverview(false)
+}
+
+// MarshalOverviewWithConfigs 与 MarshalOverview 相同，但额外为每个资源输出 "config" 字段。
+//
+// 配置通过 encoding/json 编码，可能包含敏感信息，只应在可信环境中使用。
+// 配置无法编码为 JSON 时返回错误。
+func (m *manager[C, T]) MarshalOverviewWithConfigs() ([]byte, error) {
+	return m.marshalOverview(true)
+}
+
+// marshalOverview 在读锁内采集概览并编码为 JSON。
+func (m *manager[C, T]) marshalOverview(withConfigs bool) ([]byte, error) {
+	m.mu.RLock()
+	ov := overview{Groups: make(map[string]groupOverview, len(m.groups))}
+	for groupName, groupMap := range m.groups {
+		resources := make(map[string]resourceOverview, len(groupMap))
+		for name, conn := range groupMap {
+			r := resourceOverview{Ready: conn.ready}
+			if withConfigs {
+				r.Config = conn.cfg
+			}
+			resources[name] = r
+		}
+		ov.Groups[groupName] = groupOverview{Resources: resources}
+	}
+	m.mu.RUnlock()
+
+	return json.Marshal(ov)
+}
@@ -0,0 +1,195 @@
+package registry
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultPoolSize 是未通过 WithPoolSize 配置时资源池的默认大小。
+const defaultPoolSize = 1
+
+// WithPoolSize 为指定名称的资源配置池大小，供 Group.Acquire 使用。
+//
+// 池大小只按资源名配置，对所有组中同名的资源都生效，无法为单个组单独设置：
+// 每个组中的同名资源各自拥有独立的池，互不共享实例，每个池最多通过 opener 创建 n 个实例。
+// Acquire 在池中实例均被占用且数量已达上限时阻塞，直到有实例被归还或 ctx 结束。
+// n 小于 1 时按 1 处理。未配置的资源池大小为 1。
+//
+// 示例:
+//
+//	mgr := registry.NewManager(opener, closer, registry.WithPoolSize[DBConfig, *sql.Conn]("main", 4))
+func WithPoolSize[C any, T any](name string, n int) Option[C, T] {
+	return func(m *manager[C, T]) {
+		if m.poolSizes == nil {
+			m.poolSizes = make(map[string]int)
+		}
+		m.poolSizes[name] = max(n, defaultPoolSize)
+	}
+}
+
+// pool 是单个资源的实例池，与 Get 缓存的共享实例相互独立。
+//
+// pool 使用自身的锁保护内部状态，阻塞等待期间不会持有 manager 的锁。
+//
+// 类型参数:
+//   - T: 资源类型
+type pool[T any] struct {
+	sem chan struct{} // sem 是容量为池大小的信号量，每个被借出的实例占用一个位置
+
+	mu     sync.Mutex // mu 保护以下字段
+	idle   []T        // idle 是当前空闲、可被复用的实例
+	all    []T        // all 是池中创建过的全部实例，用于关闭
+	closed bool       // closed 标记池是否已被关闭
+}
+
+// newPool 创建一个指定大小的实例池。
+func newPool[T any](size int) *pool[T] {
+	return &pool[T]{sem: make(chan struct{}, size)}
+}
+
+// acquire 从池中借出一个实例，必要时通过 open 创建新实例。
+//
+// 若池在 open 执行期间被关闭，新实例不会再被 close 返回，此时通过 discard 关闭它并返回 errPoolClosed。
+func (p *pool[T]) acquire(ctx context.Context, open func(ctx context.Context) (T, error), discard func(val T)) (T, func(), error) {
+	var zero T
+
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return zero, nil, ctx.Err()
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.sem
+		return zero, nil, errPoolClosed
+	}
+	if n := len(p.idle); n > 0 {
+		val := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		return val, p.releaseFunc(val), nil
+	}
+	p.mu.Unlock()
+
+	val, err := open(ctx)
+	if err != nil {
+		<-p.sem
+		return zero, nil, err
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.sem
+		discard(val)
+		return zero, nil, errPoolClosed
+	}
+	p.all = append(p.all, val)
+	p.mu.Unlock()
+	return val, p.releaseFunc(val), nil
+}
+
+// releaseFunc 返回将 val 归还到池中的函数，多次调用只会生效一次。
+func (p *pool[T]) releaseFunc(val T) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			if !p.closed {
+				p.idle = append(p.idle, val)
+			}
+			p.mu.Unlock()
+			<-p.sem
+		})
+	}
+}
+
+// close 标记池为已关闭，并返回池中创建过的全部实例以便调用方关闭。
+func (p *pool[T]) close() []T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	all := p.all
+	p.all, p.idle = nil, nil
+	return all
+}
+
+// Acquire 从资源池中借出一个资源实例。
+//
+// 与 Get 返回的共享实例不同，Acquire 借出的实例在归还前由调用方独占。
+// 池的大小通过 WithPoolSize 配置（默认 1）：有空闲实例时直接复用；
+// 实例数未达上限时调用 opener 创建新实例；否则阻塞等待，直到有实例被归还或 ctx 结束。
+//
+// 返回值:
+//   - T: 借出的资源实例
+//   - func(): 归还函数，使用完毕后必须调用，多次调用只会生效一次
+//   - error: 可能为 ErrGroupNotFound、ErrResourceNotFound、ctx 的错误，
+//     或创建实例失败时的 ErrOpenResourceFailed（包装了 opener 返回的原始错误）
+//
+// 池中的全部实例会在 Unregister、Group.Close 或 Manager.Close 时通过 closer 关闭。
+func (g *group[C, T]) Acquire(ctx context.Context, name string) (T, func(), error) {
+	var zero T
+
+	g.m.mu.Lock()
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		g.m.mu.Unlock()
+		return zero, nil, NewErrGroupNotFound(g.name)
+	}
+	conn, ok := groupMap[name]
+	if !ok {
+		g.m.mu.Unlock()
+		return zero, nil, NewErrResourceNotFound(g.name, name)
+	}
+	if conn.pool == nil {
+		conn.pool = newPool[T](g.m.poolSize(name))
+	}
+	p, cfg := conn.pool, conn.cfg
+	g.m.mu.Unlock()
+
+	val, release, err := p.acquire(ctx, func(ctx context.Context) (T, error) {
+		val, err := g.m.open(ctx, g.name, name, cfg)
+		if err != nil {
+			return val, NewErrOpenResourceFailed(g.name, name, err)
+		}
+		return val, nil
+	}, func(val T) {
+		// 资源在创建期间被注销或关闭，丢弃新实例
+		_ = g.m.callCloser(withResource(ctx, g.name, name), g.name, name, val)
+	})
+	if err == errPoolClosed {
+		return zero, nil, NewErrResourceNotFound(g.name, name)
+	}
+	return val, release, err
+}
+
+// poolSize 返回指定名称资源的池大小。
+func (m *manager[C, T]) poolSize(name string) int {
+	if n, ok := m.poolSizes[name]; ok {
+		return n
+	}
+	return defaultPoolSize
+}
+
+// closePool 关闭连接的资源池中的全部实例，返回关闭过程中的错误。
+//
+// 调用方必须持有 manager 的写锁。
+func (m *manager[C, T]) closePool(ctx context.Context, groupName, name string, conn *connection[C, T]) []error {
+	if conn.pool == nil {
+		return nil
+	}
+	vals := conn.pool.close()
+	conn.pool = nil
+	if m.closer == nil {
+		return nil
+	}
+	var errs []error
+	for _, val := range vals {
+		if err := m.callCloser(ctx, groupName, name, val); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,261 @@
+package registry
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// prefixGroup 是 Group.WithPrefix 返回的带名称前缀的组视图。
+//
+// 所有传入的资源名都会自动加上 prefix 后委托给底层组，返回的资源名则去掉 prefix；
+// 遍历类操作只处理名称以 prefix 开头的资源。
+type prefixGroup[C any, T any] struct {
+	g      *group[C, T]
+	prefix string
+}
+
+// WithPrefix 返回一个以 prefix 为作用域的组视图，适用于 "tenantA:db" 这类多租户命名。
+//
+// 视图上的操作会自动为资源名加上 prefix 后委托给原组，返回的资源名会去掉 prefix：
+// List、Configs、Select、FindByTag、ForEachConcurrent 等只包含名称以 prefix 开头的资源，
+// 在视图中注册的资源在原组中以完整名称可见。错误信息中的资源名为带前缀的完整名称。
+// GetWithDeps 传给 DependentOpener 的依赖 map 同样以去掉前缀的资源名为 key。
+//
+// 视图的 Close 和 Reset 只注销作用域内的资源，不会移除整个组；CloseResources、GetByKey、PingAny
+// 同样只作用于作用域内的资源。在视图上再次调用 WithPrefix 会叠加前缀。
+//
+// 示例:
+//
+//	tenant := group.WithPrefix("tenantA:")
+//	tenant.Register(ctx, "db", cfg)   // 在原组中注册为 "tenantA:db"
+//	db, err := tenant.Get(ctx, "db")  // 等价于 group.Get(ctx, "tenantA:db")
+//	names := tenant.List()            // ["db"]
+func (g *group[C, T]) WithPrefix(prefix string) Group[C, T] {
+	return &prefixGroup[C, T]{g: g, prefix: prefix}
+}
+
+// full 返回带前缀的完整资源名。
+func (p *prefixGroup[C, T]) full(name string) string {
+	return p.prefix + name
+}
+
+// fullNames 为每个资源名加上前缀。
+func (p *prefixGroup[C, T]) fullNames(names []string) []string {
+	r := make([]string, len(names))
+	for i, name := range names {
+		r[i] = p.full(name)
+	}
+	return r
+}
+
+// match 判断完整资源名是否属于当前作用域。
+func (p *prefixGroup[C, T]) match(name string) bool {
+	return strings.HasPrefix(name, p.prefix)
+}
+
+// strip 去掉完整资源名的前缀。
+func (p *prefixGroup[C, T]) strip(name string) string {
+	return strings.TrimPrefix(name, p.prefix)
+}
+
+// scoped 过滤出属于当前作用域的资源名并去掉前缀。
+func (p *prefixGroup[C, T]) scoped(names []string) []string {
+	var r []string
+	for _, name := range names {
+		if p.match(name) {
+			r = append(r, p.strip(name))
+		}
+	}
+	return r
+}
+
+func (p *prefixGroup[C, T]) Get(ctx context.Context, name string) (T, error) {
+	return p.g.Get(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) GetFirstAvailable(ctx context.Context, names ...string) (string, T, error) {
+	name, val, err := p.g.GetFirstAvailable(ctx, p.fullNames(names)...)
+	if err != nil {
+		return "", val, err
+	}
+	return p.strip(name), val, nil
+}
+
+func (p *prefixGroup[C, T]) GetByKey(ctx context.Context, routingKey string) (string, T, error) {
+	name, val, err := p.g.getByKey(ctx, routingKey, p.match)
+	if err != nil {
+		return "", val, err
+	}
+	return p.strip(name), val, nil
+}
+
+func (p *prefixGroup[C, T]) ForEachConcurrent(ctx context.Context, concurrency int, fn func(ctx context.Context, name string, val T) error) error {
+	return p.g.ForEachConcurrent(ctx, concurrency, func(ctx context.Context, name string, val T) error {
+		if !p.match(name) {
+			return nil
+		}
+		return fn(ctx, p.strip(name), val)
+	})
+}
+
+func (p *prefixGroup[C, T]) GetWithDeps(ctx context.Context, name string, deps ...string) (T, error) {
+	return p.g.getWithDeps(ctx, p.full(name), p.fullNames(deps), p.strip)
+}
+
+func (p *prefixGroup[C, T]) GetFresh(ctx context.Context, name string) (T, error) {
+	return p.g.GetFresh(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) GetScoped(ctx context.Context, name string) (T, error) {
+	return p.g.GetScoped(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) MustGet(ctx context.Context, name string) T {
+	return p.g.MustGet(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) GetOrZero(ctx context.Context, name string) T {
+	return p.g.GetOrZero(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) Config(ctx context.Context, name string) (C, error) {
+	return p.g.Config(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) MustConfig(ctx context.Context, name string) C {
+	return p.g.MustConfig(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) Configs() map[string]C {
+	r := make(map[string]C)
+	for name, cfg := range p.g.Configs() {
+		if p.match(name) {
+			r[p.strip(name)] = cfg
+		}
+	}
+	return r
+}
+
+func (p *prefixGroup[C, T]) Register(ctx context.Context, name string, cfg C) (bool, error) {
+	return p.g.Register(ctx, p.full(name), cfg)
+}
+
+func (p *prefixGroup[C, T]) Replace(ctx context.Context, name string, cfg C) (T, error) {
+	return p.g.Replace(ctx, p.full(name), cfg)
+}
+
+func (p *prefixGroup[C, T]) CanRegister(name string, cfg C) (bool, error) {
+	return p.g.CanRegister(p.full(name), cfg)
+}
+
+func (p *prefixGroup[C, T]) ComputeIfAbsent(ctx context.Context, name string, cfgFn func() C) (T, error) {
+	return p.g.ComputeIfAbsent(ctx, p.full(name), cfgFn)
+}
+
+func (p *prefixGroup[C, T]) RegisterTagged(ctx context.Context, name string, cfg C, tags map[string]string) (bool, error) {
+	return p.g.RegisterTagged(ctx, p.full(name), cfg, tags)
+}
+
+func (p *prefixGroup[C, T]) RegisterWithTTL(ctx context.Context, name string, cfg C, ttl time.Duration) (bool, error) {
+	return p.g.RegisterWithTTL(ctx, p.full(name), cfg, ttl)
+}
+
+func (p *prefixGroup[C, T]) FindByTag(key, value string) []string {
+	return p.scoped(p.g.FindByTag(key, value))
+}
+
+func (p *prefixGroup[C, T]) Tags(name string) (map[string]string, error) {
+	return p.g.Tags(p.full(name))
+}
+
+func (p *prefixGroup[C, T]) OpenCount(name string) uint64 {
+	return p.g.OpenCount(p.full(name))
+}
+
+func (p *prefixGroup[C, T]) LastError(name string) (error, time.Time, bool) {
+	return p.g.LastError(p.full(name))
+}
+
+func (p *prefixGroup[C, T]) Touch(name string) error {
+	return p.g.Touch(p.full(name))
+}
+
+func (p *prefixGroup[C, T]) LastAccess(name string) (time.Time, bool) {
+	return p.g.LastAccess(p.full(name))
+}
+
+func (p *prefixGroup[C, T]) View(name string) (ResourceView[C, T], error) {
+	v, err := p.g.View(p.full(name))
+	if err != nil {
+		return v, err
+	}
+	v.Name = name
+	return v, nil
+}
+
+func (p *prefixGroup[C, T]) Unregister(ctx context.Context, name string) error {
+	return p.g.Unregister(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) UnregisterWhere(ctx context.Context, pred func(name string, cfg C) bool) []error {
+	return p.g.UnregisterWhere(ctx, func(name string, cfg C) bool {
+		return p.match(name) && pred(p.strip(name), cfg)
+	})
+}
+
+func (p *prefixGroup[C, T]) List() []string {
+	return p.scoped(p.g.List())
+}
+
+func (p *prefixGroup[C, T]) Select(pred func(name string, cfg C) bool) []string {
+	return p.scoped(p.g.Select(func(name string, cfg C) bool {
+		return p.match(name) && pred(p.strip(name), cfg)
+	}))
+}
+
+// Close 关闭并注销作用域内的所有资源，底层组保留。
+func (p *prefixGroup[C, T]) Close(ctx context.Context) []error {
+	return p.Reset(ctx)
+}
+
+func (p *prefixGroup[C, T]) CloseResources(ctx context.Context) []error {
+	return p.g.closeResources(ctx, p.match)
+}
+
+// Reset 关闭并注销作用域内的所有资源。
+func (p *prefixGroup[C, T]) Reset(ctx context.Context) []error {
+	return p.g.UnregisterWhere(ctx, func(name string, _ C) bool {
+		return p.match(name)
+	})
+}
+
+func (p *prefixGroup[C, T]) Acquire(ctx context.Context, name string) (T, func(), error) {
+	return p.g.Acquire(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) OnReadyChange(name string, cb func(ready bool)) func() {
+	return p.g.OnReadyChange(p.full(name), cb)
+}
+
+func (p *prefixGroup[C, T]) Ping(ctx context.Context, name string) error {
+	return p.g.Ping(ctx, p.full(name))
+}
+
+func (p *prefixGroup[C, T]) PingAny(ctx context.Context) error {
+	return p.g.pingAny(ctx, p.match)
+}
+
+// Generation 返回底层组的变更代数，作用域外资源的变更同样会使其递增。
+func (p *prefixGroup[C, T]) Generation() uint64 {
+	return p.g.Generation()
+}
+
+func (p *prefixGroup[C, T]) AwaitAll(ctx context.Context) error {
+	return p.g.awaitAll(ctx, p.match)
+}
+
+// WithPrefix 返回叠加了 prefix 的新视图。
+func (p *prefixGroup[C, T]) WithPrefix(prefix string) Group[C, T] {
+	return &prefixGroup[C, T]{g: p.g, prefix: p.prefix + prefix}
+}
@@ -2,8 +2,14 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // defaultGroupName 是使用 NewGroup 创建单组资源管理器时的默认组名。
@@ -18,16 +24,36 @@ const defaultGroupName = "defaultGroup"
 // 参数:
 //   - opener: 资源打开器，用于根据配置创建资源实例
 //   - closer: 资源关闭器，用于关闭/销毁资源（可以为 nil）
+//   - opts: 可选配置项，如 WithStrictGroups
 //
 // 类型参数:
 //   - C: 配置类型
 //   - T: 资源类型
-func NewManager[C any, T any](opener Opener[C, T], closer Closer[T]) Manager[C, T] {
-	return &manager[C, T]{
+func NewManager[C any, T any](opener Opener[C, T], closer Closer[T], opts ...Option[C, T]) Manager[C, T] {
+	return newManager(opener, closer, opts...)
+}
+
+// newManager 创建 manager 实例并依次应用所有配置项。
+func newManager[C any, T any](opener Opener[C, T], closer Closer[T], opts ...Option[C, T]) *manager[C, T] {
+	m := &manager[C, T]{
 		groups: make(map[string]map[string]*connection[C, T]),
 		opener: opener,
 		closer: closer,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.opener = m.wrapOpener(m.opener)
+	return m
+}
+
+// wrapOpener 使用 WithOpenerMiddleware 添加的中间件包装 opener。
+func (m *manager[C, T]) wrapOpener(opener Opener[C, T]) Opener[C, T] {
+	// 逆序包装，使先注册的中间件位于最外层
+	for i := len(m.middlewares) - 1; i >= 0; i-- {
+		opener = m.middlewares[i](opener)
+	}
+	return opener
 }
 
 // connection 表示一个资源连接的内部状态。
@@ -36,9 +62,28 @@ func NewManager[C any, T any](opener Opener[C, T], closer Closer[T]) Manager[C,
 //   - C: 配置类型
 //   - T: 资源类型
 type connection[C any, T any] struct {
-	cfg   C    // cfg 是创建资源所需的配置
-	val   T    // val 是已创建的资源实例
-	ready bool // ready 标记资源是否已通过 opener 完成初始化
+	cfg     C             // cfg 是创建资源所需的配置
+	val     T             // val 是已创建的资源实例
+	ready   bool          // ready 标记资源是否已通过 opener 完成初始化
+	warming chan struct{} // warming 在 WarmupAll 于锁外创建共享实例期间非 nil，创建结束时关闭
+	pool    *pool[T]      // pool 是 Acquire 使用的实例池，首次 Acquire 时创建
+
+	tags map[string]string // tags 是通过 RegisterTagged 附加的标签（可为 nil）
+
+	opens atomic.Uint64 // opens 是为该资源共享实例调用 opener 的累计次数
+
+	lastAccess atomic.Int64 // lastAccess 是最近一次通过 Get 成功获取或 Touch 的时间（UnixNano），0 表示从未访问
+
+	lastErr   error     // lastErr 是最近一次 opener 失败的错误，成功初始化后清空
+	lastErrAt time.Time // lastErrAt 是 lastErr 发生的时间
+
+	ttl      time.Duration // ttl 是通过 RegisterWithTTL 设置的共享实例最大存活时间，0 表示不过期
+	openedAt time.Time     // openedAt 是当前共享实例的创建时间
+}
+
+// touch 将资源的最近访问时间更新为 now，只需持有读锁。
+func (c *connection[C, T]) touch(now time.Time) {
+	c.lastAccess.Store(now.UnixNano())
 }
 
 // manager 是 Manager 接口的具体实现，负责管理多个资源组。
@@ -52,6 +97,33 @@ type manager[C any, T any] struct {
 
 	opener Opener[C, T] // opener 用于创建资源实例
 	closer Closer[T]    // closer 用于关闭资源实例（可为 nil）
+
+	strictGroups  bool           // strictGroups 为 true 时，Register 不会自动重建不存在的组
+	frozen        bool           // frozen 为 true 时禁止注册和注销资源，由 Freeze 设置、Close 清除
+	recoverPanics bool           // recoverPanics 为 true 时将 opener 的 panic 转换为 ErrOpenerPanicked 错误
+	recoverCloser bool           // recoverCloser 为 true 时将 closer 的 panic 转换为 ErrCloserPanicked 错误
+	poolSizes     map[string]int // poolSizes 记录通过 WithPoolSize 配置的资源池大小，key 为资源名
+	validator     func(C) error  // validator 在注册时校验配置（可为 nil）
+
+	middlewares []func(next Opener[C, T]) Opener[C, T] // middlewares 是通过 WithOpenerMiddleware 添加的中间件，构造时组合进 opener
+	depOpener   DependentOpener[C, T]                  // depOpener 是 GetWithDeps 使用的打开器（可为 nil）
+
+	closeOrderFn func(names []string) []string // closeOrderFn 决定 Close 时组内资源的关闭顺序（可为 nil）
+	events       *eventLog                     // events 是通过 WithEventLog 启用的事件日志（可为 nil）
+	now          func() time.Time              // now 返回管理器使用的当前时间，通过 WithClock 设置，为 nil 时使用 time.Now
+
+	sortedGroupNames []string // sortedGroupNames 缓存按升序排列的组名，供 ListGroupNamesPage 使用；组增删时置为 nil
+
+	generation atomic.Uint64 // generation 是管理器的变更代数，任意组发生变更时递增
+	groupGens  sync.Map      // groupGens 保存各组的变更代数，key 为组名，value 为 *atomic.Uint64
+
+	openSuccesses atomic.Uint64 // openSuccesses 是 opener 成功创建资源的累计次数
+	openFailures  atomic.Uint64 // openFailures 是 opener 返回错误的累计次数
+
+	readySubs    subscribers[bool]  // readySubs 保存 OnReadyChange 注册的回调
+	pendingReady []readyChange      // pendingReady 是持有写锁期间待通知的就绪状态变化
+	awaitSubs    subscribers[error] // awaitSubs 保存 AwaitAll 的内部订阅，接收初始化失败和资源移除通知
+	pendingAwait []awaitChange      // pendingAwait 是持有写锁期间待通知 AwaitAll 的变化
 }
 
 // Group 根据名称获取资源组。
@@ -78,33 +150,209 @@ func (m *manager[C, T]) Group(name string) (Group[C, T], error) {
 // 遍历所有组中的所有资源，对已初始化（ready=true）的资源调用 closer 进行关闭。
 // 关闭完成后，管理器将被重置为空状态（所有组和资源配置都会被清除）。
 //
+// 每关闭一个资源前都会检查 ctx：若 ctx 已取消或超时，立即停止并追加一个 ErrCloseInterrupted 错误
+// （可通过 errors.Is 判断 context.Canceled 或 context.DeadlineExceeded），
+// 已关闭的资源会被移除，尚未处理的资源和组仍保留在管理器中，可在之后再次调用 Close。
+//
 // 返回值:
 //   - []error: 关闭过程中遇到的所有错误，每个错误都包含组名和资源名信息
 func (m *manager[C, T]) Close(ctx context.Context) []error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	defer m.unlockAndNotify()
 
 	var errs []error
 
 	for groupName, groupMap := range m.groups {
-		for name, conn := range groupMap {
-			if !conn.ready {
-				continue
-			}
-			if m.closer == nil {
-				continue
-			}
-			if err := m.closer(ctx, conn.val); err != nil {
-				errs = append(errs, NewErrCloseResourceFailed(groupName, name, err))
-			}
+		groupErrs, done := m.closeGroupMap(ctx, groupName, groupMap)
+		errs = append(errs, groupErrs...)
+		if !done {
+			return errs
+		}
+		delete(m.groups, groupName)
+		m.sortedGroupNames = nil
+		m.bumpGeneration(groupName)
+	}
+	m.frozen = false
+	return errs
+}
+
+// ClosePerGroup 关闭管理器中所有已初始化的资源，并按组名返回关闭错误。
+//
+// 与 Close 在 ctx 取消时立即返回不同，ClosePerGroup 会对每个组都尝试关闭，
+// 某个组失败或被中断不会影响其他组的处理。关闭期间不会修改任何组的资源表，
+// 所有组都尝试完毕后，才在一次遍历中统一移除已关闭的资源和已全部关闭的组。
+// 被 ctx 中断的组会记录 ErrCloseInterrupted，组和其中尚未关闭的资源保留在管理器中。
+// 所有组都关闭完成后，管理器被重置为空状态，行为与 Close 一致。
+//
+// 返回值:
+//   - map[string][]error: key 为组名，value 为该组的关闭错误；只包含出错的组，全部成功时返回空 map
+//
+// 示例:
+//
+//	for group, errs := range mgr.ClosePerGroup(ctx) {
+//	    log.Printf("close group %s: %v", group, errors.Join(errs...))
+//	}
+func (m *manager[C, T]) ClosePerGroup(ctx context.Context) map[string][]error {
+	m.mu.Lock()
+	defer m.unlockAndNotify()
+
+	results := make(map[string][]error)
+	closed := make(map[string][]string, len(m.groups))
+	var done []string
+	for groupName, groupMap := range m.groups {
+		errs, names, ok := m.closeGroupConns(ctx, groupName, groupMap)
+		if len(errs) > 0 {
+			results[groupName] = errs
+		}
+		closed[groupName] = names
+		if ok {
+			done = append(done, groupName)
 		}
 	}
 
-	// 清空所有组
-	m.groups = make(map[string]map[string]*connection[C, T])
+	// 所有组都尝试关闭后再统一清理
+	for groupName, names := range closed {
+		for _, name := range names {
+			delete(m.groups[groupName], name)
+			m.bumpGeneration(groupName)
+		}
+	}
+	for _, groupName := range done {
+		delete(m.groups, groupName)
+		m.sortedGroupNames = nil
+		m.bumpGeneration(groupName)
+	}
+	if len(m.groups) == 0 {
+		m.frozen = false
+	}
+	return results
+}
+
+// closeGroupMap 逐个关闭组内的资源，并将已处理的资源从 groupMap 中移除。
+//
+// 每处理一个资源前都会检查 ctx，若已取消则停止并返回 done=false，未处理的资源保留在 groupMap 中。
+// 调用方必须持有 manager 的写锁。
+func (m *manager[C, T]) closeGroupMap(ctx context.Context, groupName string, groupMap map[string]*connection[C, T]) (errs []error, done bool) {
+	errs, closed, done := m.closeGroupConns(ctx, groupName, groupMap)
+	for _, name := range closed {
+		delete(groupMap, name)
+		m.bumpGeneration(groupName)
+	}
+	return errs, done
+}
+
+// closeGroupConns 按关闭顺序逐个关闭组内的资源，但不修改 groupMap，返回已关闭的资源名，由调用方负责移除。
+//
+// 每处理一个资源前都会检查 ctx，若已取消则停止并返回 done=false。
+// 调用方必须持有 manager 的写锁。
+func (m *manager[C, T]) closeGroupConns(ctx context.Context, groupName string, groupMap map[string]*connection[C, T]) (errs []error, closed []string, done bool) {
+	seen := make(map[string]bool, len(groupMap))
+	for _, name := range m.closeOrder(groupMap) {
+		// 关闭顺序函数可能返回未知或重复的资源名
+		conn, ok := groupMap[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		if err := ctx.Err(); err != nil {
+			return append(errs, NewErrCloseInterrupted(groupName, err)), closed, false
+		}
+		errs = append(errs, m.closeConn(ctx, groupName, name, conn)...)
+		closed = append(closed, name)
+	}
+	return errs, closed, true
+}
+
+// closeOrder 返回关闭组内资源的顺序。
+//
+// 未设置 WithCloseOrder 时顺序不确定；设置后按其返回的顺序关闭，
+// 其中遗漏的资源名按升序追加在末尾，保证所有资源都会被关闭。
+func (m *manager[C, T]) closeOrder(groupMap map[string]*connection[C, T]) []string {
+	names := make([]string, 0, len(groupMap))
+	for name := range groupMap {
+		names = append(names, name)
+	}
+	if m.closeOrderFn == nil {
+		return names
+	}
+
+	sort.Strings(names)
+	ordered := m.closeOrderFn(slices.Clone(names))
+	seen := make(map[string]bool, len(ordered))
+	for _, name := range ordered {
+		seen[name] = true
+	}
+	for _, name := range names {
+		if !seen[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+// closeConn 关闭单个资源的共享实例及其资源池，返回包装后的关闭错误。
+//
+// 调用方必须持有 manager 的写锁。
+func (m *manager[C, T]) closeConn(ctx context.Context, groupName, name string, conn *connection[C, T]) []error {
+	ctx = withResource(ctx, groupName, name)
+	var errs []error
+	for _, err := range m.closePool(ctx, groupName, name, conn) {
+		errs = append(errs, NewErrCloseResourceFailed(groupName, name, err))
+	}
+	if !conn.ready {
+		// 未就绪的资源不需要关闭实例，但正在等待它的 AwaitAll 需要得知其已被移除
+		m.queueAwaitChange(groupName, name, nil)
+		return errs
+	}
+	closeErr := m.callCloser(ctx, groupName, name, conn.val)
+	if closeErr != nil {
+		errs = append(errs, NewErrCloseResourceFailed(groupName, name, closeErr))
+	}
+	m.recordEvent(EventClose, groupName, name, closeErr)
+	m.queueReadyChange(groupName, name, false)
 	return errs
 }
 
+// UnregisterEverywhere 在所有组中注销指定名称的资源。
+//
+// 适用于同一逻辑资源名分布在多个组（如多个分片中的 "primary"）时的统一清理。
+// 已初始化的资源会先调用 Closer 关闭；资源在某个组中不存在不视为错误。
+// 所有组在同一次写锁内处理。
+//
+// 返回值:
+//   - map[string]error: 关闭失败的组及其错误（包装为 ErrCloseResourceFailed），key 为组名；
+//     全部成功时返回空 map
+//
+// 示例:
+//
+//	errs := mgr.UnregisterEverywhere(ctx, "primary")
+//	for group, err := range errs {
+//	    log.Printf("close primary in %s: %v", group, err)
+//	}
+func (m *manager[C, T]) UnregisterEverywhere(ctx context.Context, name string) map[string]error {
+	m.mu.Lock()
+	defer m.unlockAndNotify()
+
+	results := make(map[string]error)
+	for groupName, groupMap := range m.groups {
+		conn, ok := groupMap[name]
+		if !ok {
+			continue
+		}
+		if m.frozen {
+			results[groupName] = NewErrFrozen(groupName)
+			continue
+		}
+		if errs := m.closeConn(ctx, groupName, name, conn); len(errs) > 0 {
+			results[groupName] = errors.Join(errs...)
+		}
+		delete(groupMap, name)
+		m.recordEvent(EventUnregister, groupName, name, nil)
+		m.bumpGeneration(groupName)
+	}
+	return results
+}
+
 // MustGroup 根据名称获取资源组，如果组不存在则触发 panic。
 //
 // 此方法是 Group 的便捷封装，适用于确定组一定存在的场景。
@@ -122,18 +370,41 @@ func (m *manager[C, T]) MustGroup(name string) Group[C, T] {
 // 如果指定名称的组不存在，则创建一个新的空组。
 // 如果组已存在，不会进行任何操作。
 //
+// 管理器被 Freeze 冻结后不会创建新组，但由于返回值无法表达错误，此时仍返回 false；
+// 需要区分这种情况时请使用 AddGroupE。
+//
 // 返回值:
-//   - false: 组是新创建的
+//   - false: 组是新创建的（或因冻结未能创建）
 //   - true: 组已经存在（未做任何修改）
 func (m *manager[C, T]) AddGroup(name string) bool {
+	existed, _ := m.AddGroupE(name)
+	return existed
+}
+
+// AddGroupE 与 AddGroup 相同，但在管理器已冻结且组不存在时返回 ErrFrozen。
+//
+// 返回值:
+//   - existed: true 表示组已经存在（未做任何修改），false 表示组是新创建的或未能创建
+//   - err: 管理器已冻结且组不存在时返回 ErrFrozen，组未被创建；否则为 nil
+//
+// 示例:
+//
+//	if _, err := mgr.AddGroupE("reporting"); errors.Is(err, registry.ErrFrozen) {
+//	    // 管理器已冻结，无法添加新组
+//	}
+func (m *manager[C, T]) AddGroupE(name string) (existed bool, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	_, ok := m.groups[name]
-	if !ok {
-		m.groups[name] = make(map[string]*connection[C, T])
-		return false
+	if _, ok := m.groups[name]; ok {
+		return true, nil
 	}
-	return true
+	if m.frozen {
+		return false, NewErrFrozen(name)
+	}
+	m.groups[name] = make(map[string]*connection[C, T])
+	m.sortedGroupNames = nil
+	m.bumpGeneration(name)
+	return false, nil
 }
 
 // ListGroupNames 返回所有已注册的组名列表。
@@ -149,6 +420,69 @@ func (m *manager[C, T]) ListGroupNames() []string {
 	return groupNames
 }
 
+// ListGroupNamesPage 按组名升序分页返回组名，同时返回组的总数，适用于管理后台分页展示。
+//
+// 组名按升序排列，组集合不变时多次调用的分页结果稳定。
+// 排序后的组名会被缓存，直到组被添加或移除，因此连续翻页时只复制当前页，不会重复排序全部组名。
+// offset 小于 0 时按 0 处理；offset 超出范围或 limit <= 0 时返回空切片（非 nil）。
+//
+// 返回值:
+//   - names: 当前页的组名
+//   - total: 组的总数
+//
+// 示例:
+//
+//	names, total := mgr.ListGroupNamesPage(20, 10) // 第 3 页，每页 10 个
+func (m *manager[C, T]) ListGroupNamesPage(offset, limit int) (names []string, total int) {
+	m.mu.RLock()
+	if m.sortedGroupNames == nil && len(m.groups) > 0 {
+		// 缓存失效，升级为写锁重建
+		m.mu.RUnlock()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.sortedGroupNames == nil {
+			m.sortedGroupNames = make([]string, 0, len(m.groups))
+			for name := range m.groups {
+				m.sortedGroupNames = append(m.sortedGroupNames, name)
+			}
+			sort.Strings(m.sortedGroupNames)
+		}
+	} else {
+		defer m.mu.RUnlock()
+	}
+
+	all := m.sortedGroupNames
+	total = len(m.groups)
+	offset = max(offset, 0)
+	if offset >= total || limit <= 0 {
+		return []string{}, total
+	}
+	end := min(offset+limit, total)
+	return slices.Clone(all[offset:end]), total
+}
+
+// GroupSummaries 返回所有组的概览信息，按组名升序排列。
+//
+// 所有组的统计在同一次读锁内完成，避免逐个调用 Group 带来的多次加锁，
+// 并保证返回的快照在各组之间是一致的。
+func (m *manager[C, T]) GroupSummaries() []GroupSummary {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	summaries := make([]GroupSummary, 0, len(m.groups))
+	for name, groupMap := range m.groups {
+		summary := GroupSummary{Name: name, Total: len(groupMap)}
+		for _, conn := range groupMap {
+			if conn.ready {
+				summary.Ready++
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries
+}
+
 // group 是 Group 接口的具体实现，代表一个资源组。
 //
 // group 通过持有 manager 的引用来访问和操作资源，
@@ -173,8 +507,13 @@ type group[C any, T any] struct {
 // 可能返回的错误:
 //   - ErrGroupNotFound: 组不存在（可能已被关闭）
 //   - ErrResourceNotFound: 资源未注册
-//   - opener 返回的错误: 资源创建失败
+//   - ErrOpenResourceFailed: 资源创建失败，同时包装了 opener 返回的原始错误
 func (g *group[C, T]) Get(ctx context.Context, name string) (T, error) {
+	return g.get(ctx, name, g.m.opener)
+}
+
+// get 是 Get 的实现，资源未初始化时通过 opener 创建。
+func (g *group[C, T]) get(ctx context.Context, name string, opener Opener[C, T]) (T, error) {
 	var zero T
 
 	// 读锁：快速路径，检查资源是否已初始化
@@ -191,8 +530,9 @@ func (g *group[C, T]) Get(ctx context.Context, name string) (T, error) {
 		return zero, NewErrResourceNotFound(g.name, name)
 	}
 
-	if conn.ready {
+	if conn.ready && !conn.expired(g.m.clock()) {
 		val := conn.val
+		conn.touch(g.m.clock())
 		g.m.mu.RUnlock()
 		return val, nil
 	}
@@ -200,7 +540,7 @@ func (g *group[C, T]) Get(ctx context.Context, name string) (T, error) {
 
 	// 写锁：慢速路径，惰性创建资源
 	g.m.mu.Lock()
-	defer g.m.mu.Unlock()
+	defer g.m.unlockAndNotify()
 
 	// 双重检查：在获取写锁期间，其他 goroutine 可能已删除组或资源
 	groupMap, ok = g.m.groups[g.name]
@@ -213,20 +553,197 @@ func (g *group[C, T]) Get(ctx context.Context, name string) (T, error) {
 		return zero, NewErrResourceNotFound(g.name, name)
 	}
 
+	return g.initConn(ctx, name, conn, opener)
+}
+
+// initConn 返回资源的共享实例，未初始化时通过 opener 创建。
+//
+// 调用方必须持有 manager 的写锁。资源正由 WarmupAll 创建时，会临时释放写锁等待其完成，
+// 保证同一时刻只有一次 opener 调用；等待期间资源被注销时返回相应的错误。
+func (g *group[C, T]) initConn(ctx context.Context, name string, conn *connection[C, T], opener Opener[C, T]) (T, error) {
+	if err := g.awaitWarmup(ctx, name, conn); err != nil {
+		var zero T
+		return zero, err
+	}
+	if conn.expired(g.m.clock()) {
+		g.m.expireConn(ctx, g.name, name, conn)
+	}
 	if conn.ready {
+		conn.touch(g.m.clock())
 		return conn.val, nil
 	}
 
-	val, err := g.m.opener(ctx, conn.cfg)
+	conn.opens.Add(1)
+	val, err := g.m.openWith(ctx, g.name, name, conn.cfg, opener)
 	if err != nil {
-		return zero, err
+		conn.lastErr, conn.lastErrAt = err, g.m.clock()
+		g.m.queueAwaitChange(g.name, name, err)
+		var zero T
+		return zero, NewErrOpenResourceFailed(g.name, name, err)
 	}
+	conn.lastErr, conn.lastErrAt = nil, time.Time{}
 
 	conn.val = val
 	conn.ready = true
+	conn.openedAt = g.m.clock()
+	conn.touch(g.m.clock())
+	g.m.queueReadyChange(g.name, name, true)
+	return val, nil
+}
+
+// GetFresh 关闭资源当前的共享实例（如果已初始化），使用当前配置重新创建并返回新实例。
+//
+// 关闭与重建在同一次写锁内完成，其他 goroutine 不会看到中间状态。
+// 关闭旧实例时 Closer 返回的错误会被忽略，不影响重建；Opener 返回的错误则会返回给调用方，
+// 此时旧实例已关闭，资源保持未初始化状态，后续 Get 会再次尝试创建。
+// OnReadyChange 回调会依次收到 false 和 true 两次通知。
+//
+// 返回值:
+//   - T: 新创建的资源实例
+//   - error: 组不存在时返回 ErrGroupNotFound，资源不存在时返回 ErrResourceNotFound，
+//     创建失败时返回 ErrOpenResourceFailed
+//
+// 示例:
+//
+//	// 数据库主从切换后强制重建连接
+//	db, err := group.GetFresh(ctx, "master")
+func (g *group[C, T]) GetFresh(ctx context.Context, name string) (T, error) {
+	g.m.mu.Lock()
+	defer g.m.unlockAndNotify()
+
+	var zero T
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return zero, NewErrGroupNotFound(g.name)
+	}
+	conn, ok := groupMap[name]
+	if !ok {
+		return zero, NewErrResourceNotFound(g.name, name)
+	}
+
+	if conn.ready {
+		closeErr := g.m.callCloser(withResource(ctx, g.name, name), g.name, name, conn.val)
+		g.m.recordEvent(EventClose, g.name, name, closeErr)
+		conn.val = zero
+		conn.ready = false
+		g.m.queueReadyChange(g.name, name, false)
+		g.m.bumpGeneration(g.name)
+	}
+	val, err := g.initConn(ctx, name, conn, g.m.opener)
+	if err != nil {
+		return zero, err
+	}
+	g.m.bumpGeneration(g.name)
+	return val, nil
+}
+
+// ComputeIfAbsent 返回指定资源的共享实例；资源未注册时先通过 cfgFn 计算配置并注册。
+//
+// 查找、注册和初始化在同一次写锁内完成，替代"先 Register 再 Get"的两步操作。
+// cfgFn 仅在资源不存在时调用；资源已存在时直接返回（必要时初始化）已有资源。
+// 并发调用同一名称时，cfgFn 和 Opener 各最多执行一次。
+// cfgFn 在写锁内执行，不能在其中调用当前管理器的方法，否则会死锁。
+//
+// 返回值:
+//   - T: 资源实例
+//   - error: 配置未通过 WithConfigValidator 校验时返回 ErrInvalidConfig（不会注册）；
+//     启用 WithStrictGroups 且组不存在时返回 ErrGroupNotFound；创建失败时返回 ErrOpenResourceFailed（配置保持注册）
+//
+// 示例:
+//
+//	db, err := group.ComputeIfAbsent(ctx, "tenant_42", func() DBConfig {
+//	    return DBConfig{DSN: dsnForTenant(42)}
+//	})
+func (g *group[C, T]) ComputeIfAbsent(ctx context.Context, name string, cfgFn func() C) (T, error) {
+	g.m.mu.Lock()
+	defer g.m.unlockAndNotify()
+
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		if g.m.strictGroups {
+			var zero T
+			return zero, NewErrGroupNotFound(g.name)
+		}
+		if g.m.frozen {
+			var zero T
+			return zero, NewErrFrozen(g.name)
+		}
+		groupMap = make(map[string]*connection[C, T])
+		g.m.groups[g.name] = groupMap
+		g.m.sortedGroupNames = nil
+	}
+
+	conn, ok := groupMap[name]
+	if !ok {
+		if g.m.frozen {
+			var zero T
+			return zero, NewErrFrozen(g.name)
+		}
+		cfg := cfgFn()
+		if err := g.m.validateConfig(g.name, name, cfg); err != nil {
+			var zero T
+			return zero, err
+		}
+		conn = &connection[C, T]{cfg: cfg}
+		groupMap[name] = conn
+		g.m.recordEvent(EventRegister, g.name, name, nil)
+		g.m.bumpGeneration(g.name)
+	}
+	return g.initConn(ctx, name, conn, g.m.opener)
+}
+
+// open 调用 opener 创建资源实例，是所有创建路径（Get、Acquire、WarmupAll 等）的统一入口。
+func (m *manager[C, T]) open(ctx context.Context, groupName, name string, cfg C) (T, error) {
+	return m.openWith(ctx, groupName, name, cfg, m.opener)
+}
+
+// openWith 使用指定的 opener 创建资源实例，并记录调用统计。
+func (m *manager[C, T]) openWith(ctx context.Context, groupName, name string, cfg C, opener Opener[C, T]) (T, error) {
+	if m.recoverPanics {
+		opener = recoverOpener(groupName, name, opener)
+	}
+	val, err := opener(withResource(ctx, groupName, name), cfg)
+	if err != nil {
+		m.openFailures.Add(1)
+		m.recordEvent(EventOpenFailed, groupName, name, err)
+		return val, err
+	}
+	m.openSuccesses.Add(1)
+	m.recordEvent(EventOpen, groupName, name, nil)
 	return val, nil
 }
 
+// recoverOpener 包装 opener，将其中的 panic 转换为 ErrOpenerPanicked 错误。
+func recoverOpener[C any, T any](groupName, name string, opener Opener[C, T]) Opener[C, T] {
+	return func(ctx context.Context, cfg C) (val T, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				var zero T
+				val, err = zero, NewErrOpenerPanicked(groupName, name, r, debug.Stack())
+			}
+		}()
+		return opener(ctx, cfg)
+	}
+}
+
+// callCloser 调用 closer 关闭 val，closer 为 nil 时返回 nil。
+//
+// 启用 WithRecoverCloserPanic 时，closer 中的 panic 会被恢复并转换为 ErrCloserPanicked 错误，
+// 避免在持有写锁时 panic 导致锁无法释放。
+func (m *manager[C, T]) callCloser(ctx context.Context, groupName, name string, val T) (err error) {
+	if m.closer == nil {
+		return nil
+	}
+	if m.recoverCloser {
+		defer func() {
+			if r := recover(); r != nil {
+				err = NewErrCloserPanicked(groupName, name, r, debug.Stack())
+			}
+		}()
+	}
+	return m.closer(ctx, val)
+}
+
 // MustGet 根据名称获取资源，如果获取失败则触发 panic。
 //
 // 此方法是 Get 的便捷封装，适用于确定资源一定存在且能成功创建的场景。
@@ -239,6 +756,26 @@ func (g *group[C, T]) MustGet(ctx context.Context, name string) T {
 	return val
 }
 
+// GetOrZero 根据名称获取资源，获取失败时返回零值而不是错误或 panic。
+//
+// 适用于资源缺失时可以降级处理的尽力而为路径。
+// 注意：错误信息会被完全丢弃，调用方无法区分组不存在、资源未注册、创建失败或 ctx 超时，
+// 也无法区分"获取失败"与"资源本身就是零值"。需要知道失败原因时请使用 Get。
+//
+// 示例:
+//
+//	if cache := group.GetOrZero(ctx, "redis"); cache != nil {
+//	    cache.Set(key, value)
+//	}
+func (g *group[C, T]) GetOrZero(ctx context.Context, name string) T {
+	val, err := g.Get(ctx, name)
+	if err != nil {
+		var zero T
+		return zero
+	}
+	return val
+}
+
 func (g *group[C, T]) Config(ctx context.Context, name string) (C, error) {
 	var zero C
 
@@ -258,6 +795,29 @@ func (g *group[C, T]) Config(ctx context.Context, name string) (C, error) {
 	return cfgCopy, nil
 }
 
+// Configs 返回组内所有已注册资源的配置快照，key 为资源名。
+//
+// 所有配置在同一次读锁内采集，比先 List 再逐个调用 Config 开销更小，且结果一致。
+// 返回的 map 是新分配的，修改它不会影响注册表；配置按值复制，引用类型字段仍与注册表共享。
+// 组不存在时返回空 map（非 nil）。
+//
+// 示例:
+//
+//	for name, cfg := range group.Configs() {
+//	    fmt.Printf("%s: %s\n", name, cfg.Host)
+//	}
+func (g *group[C, T]) Configs() map[string]C {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	groupMap := g.m.groups[g.name]
+	configs := make(map[string]C, len(groupMap))
+	for name, conn := range groupMap {
+		configs[name] = conn.cfg
+	}
+	return configs
+}
+
 func (g *group[C, T]) MustConfig(ctx context.Context, name string) C {
 	val, err := g.Config(ctx, name)
 	if err != nil {
@@ -272,29 +832,104 @@ func (g *group[C, T]) MustConfig(ctx context.Context, name string) C {
 //   - 此方法只保存配置，不会立即创建资源实例
 //   - 资源将在首次通过 Get 访问时惰性初始化
 //   - 如果资源名已存在，不会覆盖原有配置
-//   - 如果组不存在（已被关闭），会自动重新创建组
+//   - 如果组不存在（已被关闭），会自动重新创建组；
+//     启用 WithStrictGroups 时则返回 ErrGroupNotFound
+//   - 设置了 WithConfigValidator 时，会先校验配置，校验失败返回 ErrInvalidConfig
+//   - ctx 已取消或超时时不会注册，直接返回 ctx.Err()
 //
 // 返回值:
 //   - isNew: true 表示新注册成功，false 表示资源名已存在
-//   - err: 目前始终为 nil，保留用于将来扩展
+//   - err: ctx.Err()、ErrInvalidConfig、ErrFrozen 或严格分组模式下的 ErrGroupNotFound，否则为 nil
 func (g *group[C, T]) Register(ctx context.Context, name string, cfg C) (bool, error) {
+	return g.register(ctx, name, cfg, nil, 0)
+}
+
+// CanRegister 检查 Register 当前是否会成功注册指定资源，但不修改任何状态。
+//
+// 适用于配置校验工具在真正注册前预检名称是否可用、配置是否合法。
+// 检查顺序与 Register 一致：配置校验、冻结状态、组是否存在（严格模式）、名称是否已被占用。
+// 检查结果只反映调用时刻的状态，并发场景下随后的 Register 仍可能失败。
+//
+// 返回值:
+//   - ok: Register 是否会新注册该资源
+//   - reason: ok 为 false 时的原因，可能为 ErrInvalidConfig、ErrFrozen、ErrGroupNotFound 或 ErrResourceExists
+//
+// 示例:
+//
+//	if ok, reason := group.CanRegister("master", cfg); !ok {
+//	    return fmt.Errorf("invalid settings: %w", reason)
+//	}
+func (g *group[C, T]) CanRegister(name string, cfg C) (ok bool, reason error) {
+	if err := g.m.validateConfig(g.name, name, cfg); err != nil {
+		return false, err
+	}
+
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	if g.m.frozen {
+		return false, NewErrFrozen(g.name)
+	}
+	groupMap, exists := g.m.groups[g.name]
+	if !exists {
+		if g.m.strictGroups {
+			return false, NewErrGroupNotFound(g.name)
+		}
+		return true, nil
+	}
+	if _, exists := groupMap[name]; exists {
+		return false, NewErrResourceExists(g.name, name)
+	}
+	return true, nil
+}
+
+// register 是 Register、RegisterTagged 和 RegisterWithTTL 的公共实现。
+func (g *group[C, T]) register(ctx context.Context, name string, cfg C, tags map[string]string, ttl time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if err := g.m.validateConfig(g.name, name, cfg); err != nil {
+		return false, err
+	}
+
 	g.m.mu.Lock()
 	defer g.m.mu.Unlock()
 
+	if g.m.frozen {
+		return false, NewErrFrozen(g.name)
+	}
+
 	groupMap, ok := g.m.groups[g.name]
 	if !ok {
+		if g.m.strictGroups {
+			return false, NewErrGroupNotFound(g.name)
+		}
 		groupMap = make(map[string]*connection[C, T])
 		g.m.groups[g.name] = groupMap
+		g.m.sortedGroupNames = nil
 	}
 
 	if _, exists := groupMap[name]; exists {
 		return false, nil
 	}
 
-	groupMap[name] = &connection[C, T]{cfg: cfg}
+	groupMap[name] = &connection[C, T]{cfg: cfg, tags: tags, ttl: max(ttl, 0)}
+	g.m.recordEvent(EventRegister, g.name, name, nil)
+	g.m.bumpGeneration(g.name)
 	return true, nil
 }
 
+// validateConfig 使用 WithConfigValidator 设置的校验函数校验配置，未设置时始终返回 nil。
+func (m *manager[C, T]) validateConfig(groupName, name string, cfg C) error {
+	if m.validator == nil {
+		return nil
+	}
+	if err := m.validator(cfg); err != nil {
+		return NewErrInvalidConfig(groupName, name, err)
+	}
+	return nil
+}
+
 // Unregister 从组中注销指定资源。
 //
 // 如果资源已初始化（ready=true），会先调用 closer 关闭资源。
@@ -305,7 +940,11 @@ func (g *group[C, T]) Register(ctx context.Context, name string, cfg C) (bool, e
 //   - nil: 注销成功
 func (g *group[C, T]) Unregister(ctx context.Context, name string) error {
 	g.m.mu.Lock()
-	defer g.m.mu.Unlock()
+	defer g.m.unlockAndNotify()
+
+	if g.m.frozen {
+		return NewErrFrozen(g.name)
+	}
 
 	groupMap, ok := g.m.groups[g.name]
 	if !ok {
@@ -317,11 +956,11 @@ func (g *group[C, T]) Unregister(ctx context.Context, name string) error {
 		return NewErrResourceNotFound(g.name, name)
 	}
 
-	if conn.ready && g.m.closer != nil {
-		_ = g.m.closer(ctx, conn.val)
-	}
+	_ = g.m.closeConn(ctx, g.name, name, conn)
 
 	delete(groupMap, name)
+	g.m.recordEvent(EventUnregister, g.name, name, nil)
+	g.m.bumpGeneration(g.name)
 	return nil
 }
 
@@ -345,17 +984,80 @@ func (g *group[C, T]) List() []string {
 	return names
 }
 
+// Select 返回组内配置满足 pred 的所有资源名称。
+//
+// 与 FindByTag 按标签查询不同，Select 直接对注册的配置进行判断，例如查找所有指向某个主机的资源。
+// pred 在读锁内执行，不能在其中调用当前管理器的写方法，否则会死锁。
+// 返回的列表顺序不保证固定（依赖 map 遍历顺序）；组不存在或没有匹配的资源时返回空列表。
+//
+// 示例:
+//
+//	names := group.Select(func(name string, cfg DBConfig) bool {
+//	    return cfg.Host == "10.0.0.1"
+//	})
+func (g *group[C, T]) Select(pred func(name string, cfg C) bool) []string {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	var names []string
+	for name, conn := range g.m.groups[g.name] {
+		if pred(name, conn.cfg) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // Close 关闭组内所有已初始化的资源，并从管理器中移除整个组。
 //
 // 遍历组内所有资源，对已初始化（ready=true）的资源调用 closer 进行关闭。
 // 关闭完成后，整个组将从管理器中删除。
 //
+// 与 Manager.Close 相同，每关闭一个资源前都会检查 ctx；若 ctx 已结束，
+// 立即停止并追加 ErrCloseInterrupted 错误，尚未处理的资源和组本身都会保留。
+//
 // 返回值:
 //   - []error: 关闭过程中遇到的所有错误，每个错误都包含组名和资源名信息
 //   - nil: 组不存在（可能已被关闭）
 func (g *group[C, T]) Close(ctx context.Context) []error {
 	g.m.mu.Lock()
-	defer g.m.mu.Unlock()
+	defer g.m.unlockAndNotify()
+
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return nil
+	}
+
+	errs, done := g.m.closeGroupMap(ctx, g.name, groupMap)
+	if done {
+		delete(g.m.groups, g.name)
+		g.m.sortedGroupNames = nil
+		g.m.bumpGeneration(g.name)
+	}
+	return errs
+}
+
+// UnregisterWhere 注销组内所有满足条件的资源。
+//
+// 对每个资源调用 pred，返回 true 的资源会被注销；已初始化的资源会先调用 Closer 关闭。
+// 与 Unregister 不同，关闭失败的错误不会被忽略，而是包装为 ErrCloseResourceFailed 返回，资源仍会被移除。
+// pred 在写锁内执行，不能在其中调用当前管理器的方法，否则会死锁。
+//
+// 返回值:
+//   - []error: 关闭过程中遇到的所有错误；组不存在或没有匹配的资源时返回 nil
+//
+// 示例:
+//
+//	errs := g.UnregisterWhere(ctx, func(name string, cfg DBConfig) bool {
+//	    return cfg.Deprecated
+//	})
+func (g *group[C, T]) UnregisterWhere(ctx context.Context, pred func(name string, cfg C) bool) []error {
+	g.m.mu.Lock()
+	defer g.m.unlockAndNotify()
+
+	if g.m.frozen {
+		return []error{NewErrFrozen(g.name)}
+	}
 
 	groupMap, ok := g.m.groups[g.name]
 	if !ok {
@@ -364,25 +1066,88 @@ func (g *group[C, T]) Close(ctx context.Context) []error {
 
 	var errs []error
 	for name, conn := range groupMap {
-		if !conn.ready {
+		if !pred(name, conn.cfg) {
 			continue
 		}
-		if g.m.closer == nil {
+		errs = append(errs, g.m.closeConn(ctx, g.name, name, conn)...)
+		delete(groupMap, name)
+		g.m.recordEvent(EventUnregister, g.name, name, nil)
+		g.m.bumpGeneration(g.name)
+	}
+	return errs
+}
+
+// CloseResources 关闭组内所有已初始化的资源，但保留组及资源配置。
+//
+// 与 Close 不同，资源关闭后重置为未初始化状态，后续 Get 会通过 Opener 重新创建。
+// 适用于需要断开全部连接、稍后再按需重建的场景。
+// 若 ctx 在关闭过程中被取消，会提前停止并返回 ErrCloseInterrupted，未处理的资源保持原状。
+//
+// 返回值:
+//   - []error: 关闭过程中遇到的所有错误；组不存在时返回 nil
+func (g *group[C, T]) CloseResources(ctx context.Context) []error {
+	return g.closeResources(ctx, nil)
+}
+
+// closeResources 实现 CloseResources，只关闭 match 返回 true 的资源；match 为 nil 时处理全部资源。
+func (g *group[C, T]) closeResources(ctx context.Context, match func(name string) bool) []error {
+	g.m.mu.Lock()
+	defer g.m.unlockAndNotify()
+
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for name, conn := range groupMap {
+		if match != nil && !match(name) {
 			continue
 		}
-		if err := g.m.closer(ctx, conn.val); err != nil {
-			err = NewErrCloseResourceFailed(g.name, name, err)
-			errs = append(errs, err)
+		if err := ctx.Err(); err != nil {
+			return append(errs, NewErrCloseInterrupted(g.name, err))
+		}
+		wasReady := conn.ready
+		errs = append(errs, g.m.closeConn(ctx, g.name, name, conn)...)
+		var zero T
+		conn.val = zero
+		conn.ready = false
+		if wasReady {
+			g.m.bumpGeneration(g.name)
 		}
 	}
+	return errs
+}
+
+// Reset 关闭组内所有已初始化的资源并清空全部注册，但保留空组。
+//
+// 与 Close（移除整个组）和 CloseResources（保留资源配置）不同，Reset 之后组仍然存在且为空，
+// 可以直接重新 Register，严格分组模式下也无需再次 AddGroup。
+// 若 ctx 在关闭过程中被取消，会提前停止并返回 ErrCloseInterrupted，未关闭的资源保持注册。
+//
+// 返回值:
+//   - []error: 关闭过程中遇到的所有错误；组不存在时返回 nil
+func (g *group[C, T]) Reset(ctx context.Context) []error {
+	g.m.mu.Lock()
+	defer g.m.unlockAndNotify()
+
+	if g.m.frozen {
+		return []error{NewErrFrozen(g.name)}
+	}
 
-	delete(g.m.groups, g.name)
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return nil
+	}
+
+	errs, _ := g.m.closeGroupMap(ctx, g.name, groupMap)
 	return errs
 }
 
 // Ping 尝试初始化指定资源以验证可用性。
 //
 // Ping 不会修改资源的 ready 状态，也不会缓存资源实例。
+// 启用 WithRecoverOpenerPanic 时，opener 的 panic 同样会被转换为错误返回。
 // 返回 nil 表示资源可用，返回错误表示初始化失败。
 func (g *group[C, T]) Ping(ctx context.Context, name string) error {
 	g.m.mu.RLock()
@@ -403,17 +1168,18 @@ func (g *group[C, T]) Ping(ctx context.Context, name string) error {
 	g.m.mu.RUnlock()
 
 	// 调用 opener 检查资源可用性
-	cr, err := g.m.opener(ctx, cfg)
+	opener := g.m.opener
+	if g.m.recoverPanics {
+		opener = recoverOpener(g.name, name, opener)
+	}
+	ctx = withResource(ctx, g.name, name)
+	cr, err := opener(ctx, cfg)
 	if err != nil {
 		return NewErrPingResourceFailed(g.name, name, err)
 	}
-	if g.m.closer != nil {
-		err = g.m.closer(ctx, cr)
-		if err != nil {
-			err = fmt.Errorf("ping closer failed for %s: %w", name, err)
-			return NewErrCloseResourceFailed(g.name, name, err)
-		}
-
+	if err = g.m.callCloser(ctx, g.name, name, cr); err != nil {
+		err = fmt.Errorf("ping closer failed for %s: %w", name, err)
+		return NewErrCloseResourceFailed(g.name, name, err)
 	}
 	return nil
 }
@@ -430,6 +1196,7 @@ func (g *group[C, T]) Ping(ctx context.Context, name string) error {
 // 参数:
 //   - opener: 资源打开器，用于根据配置创建资源实例
 //   - closer: 资源关闭器，用于关闭/销毁资源（可以为 nil）
+//   - opts: 可选配置项，如 WithStrictGroups
 //
 // 类型参数:
 //   - C: 配置类型
@@ -443,17 +1210,37 @@ func (g *group[C, T]) Ping(ctx context.Context, name string) error {
 func New[C any, T any](
 	opener Opener[C, T],
 	closer Closer[T],
+	opts ...Option[C, T],
 ) Group[C, T] {
-	m := &manager[C, T]{
-		groups: make(map[string]map[string]*connection[C, T]),
-		opener: opener,
-		closer: closer,
-	}
+	g, _ := NewGroupWithManager(opener, closer, opts...)
+	return g
+}
+
+// NewGroupWithManager 创建一个单组资源管理器，同时返回默认组和其所属的管理器。
+//
+// 返回的 Group 是预创建的默认组，与 New 返回的组行为完全一致；
+// 返回的 Manager 是该组的上层管理器，当单组模式不再满足需求时，
+// 可以直接通过它添加更多的组，而无需重建已注册的资源。
+//
+// 示例:
+//
+//	group, mgr := NewGroupWithManager(dbOpener, dbCloser)
+//	group.Register(ctx, "main", dbConfig)
+//
+//	// 后续扩展为多组
+//	mgr.AddGroup("replica")
+//	replica := mgr.MustGroup("replica")
+func NewGroupWithManager[C any, T any](
+	opener Opener[C, T],
+	closer Closer[T],
+	opts ...Option[C, T],
+) (Group[C, T], Manager[C, T]) {
+	m := newManager(opener, closer, opts...)
 
 	// 预创建默认 group，使用 defaultGroupName 作为组名
 	m.groups[defaultGroupName] = make(map[string]*connection[C, T])
 	return &group[C, T]{
 		name: defaultGroupName,
 		m:    m,
-	}
+	}, m
 }
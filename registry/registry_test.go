@@ -2,8 +2,13 @@ package registry
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -13,6 +18,7 @@ import (
 // 编译时类型断言，确保 manager 和 group 实现了对应接口
 var _ Manager[testConfig, *testResource] = (*manager[testConfig, *testResource])(nil)
 var _ Group[testConfig, *testResource] = (*group[testConfig, *testResource])(nil)
+var _ Group[testConfig, *testResource] = (*prefixGroup[testConfig, *testResource])(nil)
 
 // 测试用的配置和资源类型
 type testConfig struct {
@@ -280,10 +286,37 @@ func TestGroup_Get_OpenerError(t *testing.T) {
 
 	_, err := g.Get(ctx, "res1")
 	if err == nil {
-		t.Error("Get should return error when opener fails")
+		t.Fatal("Get should return error when opener fails")
 	}
-	if err.Error() != "open failed" {
-		t.Errorf("expected 'open failed' error, got %v", err)
+	if !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected ErrOpenResourceFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "open failed") {
+		t.Errorf("expected error to contain 'open failed', got %v", err)
+	}
+}
+
+func TestGroup_Get_OpenerError_WrapsOriginal(t *testing.T) {
+	openErr := errors.New("dial timeout")
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		return nil, openErr
+	}
+	m := newTestManager(opener, newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	_, err := g.Get(ctx, "res1")
+	if !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected ErrOpenResourceFailed, got %v", err)
+	}
+	if !errors.Is(err, openErr) {
+		t.Errorf("expected original opener error to be preserved, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"res1"`) || !strings.Contains(err.Error(), `"group1"`) {
+		t.Errorf("expected error to mention resource and group, got %v", err)
 	}
 }
 
@@ -1288,7 +1321,8 @@ func TestContextCancellation(t *testing.T) {
 
 	m.AddGroup("group1")
 	g, _ := m.Group("group1")
-	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	// Register 会拒绝已取消的 ctx，因此使用未取消的 ctx 注册
+	g.Register(context.Background(), "res1", testConfig{Name: "res1"})
 
 	_, err := g.Get(ctx, "res1")
 	if err == nil {
@@ -1299,6 +1333,3588 @@ func TestContextCancellation(t *testing.T) {
 	}
 }
 
+// ============== WithStrictGroups 测试 ==============
+
+func TestGroup_Register_NonStrict_ResurrectsGroup(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Close(ctx)
+
+	// 默认模式下，组被关闭后 Register 会自动重新创建组
+	isNew, err := g.Register(ctx, "res1", testConfig{Name: "res1"})
+	if err != nil {
+		t.Errorf("Register should not return error: %v", err)
+	}
+	if !isNew {
+		t.Error("Register should return true for new resource")
+	}
+	if _, err := m.Group("group1"); err != nil {
+		t.Errorf("group should be recreated by Register: %v", err)
+	}
+}
+
+func TestGroup_Register_Strict_GroupNotFound(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser(), WithStrictGroups[testConfig, *testResource]())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	// 组存在时正常注册
+	if isNew, err := g.Register(ctx, "res1", testConfig{Name: "res1"}); err != nil || !isNew {
+		t.Errorf("Register should succeed, got isNew=%v err=%v", isNew, err)
+	}
+
+	g.Close(ctx)
+
+	// 严格模式下，组被关闭后 Register 应返回 ErrGroupNotFound
+	isNew, err := g.Register(ctx, "res2", testConfig{Name: "res2"})
+	if !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+	if isNew {
+		t.Error("Register should return false when group not found")
+	}
+	if _, err := m.Group("group1"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("group should not be recreated in strict mode, got %v", err)
+	}
+
+	// 显式 AddGroup 后可以继续注册
+	m.AddGroup("group1")
+	if isNew, err := g.Register(ctx, "res2", testConfig{Name: "res2"}); err != nil || !isNew {
+		t.Errorf("Register should succeed after AddGroup, got isNew=%v err=%v", isNew, err)
+	}
+}
+
+func TestNew_WithStrictGroups(t *testing.T) {
+	ctx := context.Background()
+	g := New(newTestOpener(), newTestCloser(), WithStrictGroups[testConfig, *testResource]())
+	g.Close(ctx)
+
+	if _, err := g.Register(ctx, "res1", testConfig{Name: "res1"}); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+// ============== NewGroupWithManager 测试 ==============
+
+func TestNewGroupWithManager(t *testing.T) {
+	ctx := context.Background()
+	g, mgr := NewGroupWithManager(newTestOpener(), newTestCloser())
+
+	g.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+
+	// 返回的组即为管理器中的默认组
+	names := mgr.ListGroupNames()
+	if len(names) != 1 || names[0] != defaultGroupName {
+		t.Errorf("expected only default group, got %v", names)
+	}
+
+	// 通过管理器添加第二个组
+	if existed := mgr.AddGroup("group2"); existed {
+		t.Error("AddGroup should return false for new group")
+	}
+	g2 := mgr.MustGroup("group2")
+	g2.Register(ctx, "res2", testConfig{Name: "res2", Value: 2})
+	res2, err := g2.Get(ctx, "res2")
+	if err != nil {
+		t.Fatalf("Get from second group should not return error: %v", err)
+	}
+	if res2.Config.Value != 2 {
+		t.Errorf("expected config value 2, got %d", res2.Config.Value)
+	}
+
+	// 默认组仍然正常工作
+	res1, err := g.Get(ctx, "res1")
+	if err != nil {
+		t.Fatalf("Get from default group should not return error: %v", err)
+	}
+	if res1.Config.Value != 1 {
+		t.Errorf("expected config value 1, got %d", res1.Config.Value)
+	}
+	if list := g.List(); len(list) != 1 || list[0] != "res1" {
+		t.Errorf("expected default group to contain only res1, got %v", list)
+	}
+}
+
+// ============== Acquire / WithPoolSize 测试 ==============
+
+func TestGroup_Acquire_ReleaseReuses(t *testing.T) {
+	var opens int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		atomic.AddInt32(&opens, 1)
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	r1, release, err := g.Acquire(ctx, "res1")
+	if err != nil {
+		t.Fatalf("Acquire should not return error: %v", err)
+	}
+	release()
+	release() // 重复归还不应产生影响
+
+	r2, release, err := g.Acquire(ctx, "res1")
+	if err != nil {
+		t.Fatalf("Acquire should not return error: %v", err)
+	}
+	defer release()
+	if r1 != r2 {
+		t.Error("Acquire should reuse released instance")
+	}
+	if n := atomic.LoadInt32(&opens); n != 1 {
+		t.Errorf("expected opener to be called once, got %d", n)
+	}
+}
+
+func TestGroup_Acquire_NeverExceedsPoolSize(t *testing.T) {
+	const poolSize = 2
+	var opens int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		atomic.AddInt32(&opens, 1)
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser(), WithPoolSize[testConfig, *testResource]("res1", poolSize))
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	var inFlight, maxInFlight int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, release, err := g.Acquire(ctx, "res1")
+			if err != nil {
+				t.Errorf("Acquire should not return error: %v", err)
+				return
+			}
+			cur := atomic.AddInt32(&inFlight, 1)
+			for {
+				old := atomic.LoadInt32(&maxInFlight)
+				if cur <= old || atomic.CompareAndSwapInt32(&maxInFlight, old, cur) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			release()
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&maxInFlight); n > poolSize {
+		t.Errorf("expected at most %d instances in use, got %d", poolSize, n)
+	}
+	if n := atomic.LoadInt32(&opens); n > poolSize {
+		t.Errorf("expected opener to be called at most %d times, got %d", poolSize, n)
+	}
+}
+
+func TestWithPoolSize_AppliesToSameNameInEveryGroup(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser(), WithPoolSize[testConfig, *testResource]("res1", 2))
+	ctx := context.Background()
+
+	for _, groupName := range []string{"group1", "group2"} {
+		m.AddGroup(groupName)
+		g, _ := m.Group(groupName)
+		g.Register(ctx, "res1", testConfig{Name: "res1"})
+		g.Register(ctx, "res2", testConfig{Name: "res2"})
+	}
+
+	// 每个组的同名资源各自拥有大小为 2 的池
+	for _, groupName := range []string{"group1", "group2"} {
+		g, _ := m.Group(groupName)
+		for i := 0; i < 2; i++ {
+			if _, _, err := g.Acquire(ctx, "res1"); err != nil {
+				t.Fatalf("%s: Acquire %d failed: %v", groupName, i, err)
+			}
+		}
+		timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		_, _, err := g.Acquire(timeoutCtx, "res1")
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected pool of res1 to be full, got %v", groupName, err)
+		}
+
+		// 未配置的资源使用默认大小
+		if _, _, err := g.Acquire(ctx, "res2"); err != nil {
+			t.Fatalf("%s: Acquire res2 failed: %v", groupName, err)
+		}
+		timeoutCtx, cancel = context.WithTimeout(ctx, 20*time.Millisecond)
+		_, _, err = g.Acquire(timeoutCtx, "res2")
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected default pool of res2 to be full, got %v", groupName, err)
+		}
+	}
+}
+
+func TestGroup_Acquire_BlocksUntilContextDone(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	_, release, err := g.Acquire(ctx, "res1")
+	if err != nil {
+		t.Fatalf("Acquire should not return error: %v", err)
+	}
+	defer release()
+
+	// 默认池大小为 1，已被占用时再次 Acquire 应阻塞直到超时
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := g.Acquire(tctx, "res1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestGroup_Acquire_Errors(t *testing.T) {
+	m := newManager(newFailingOpener("open failed"), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	if _, _, err := g.Acquire(ctx, "nonexistent"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+	if _, _, err := g.Acquire(ctx, "res1"); !errors.Is(err, ErrOpenResourceFailed) || !strings.Contains(err.Error(), "open failed") {
+		t.Errorf("expected ErrOpenResourceFailed wrapping the opener error, got %v", err)
+	}
+	// opener 失败后不应占用池的位置
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := g.Acquire(tctx, "res1"); errors.Is(err, context.DeadlineExceeded) {
+		t.Error("failed open should release its pool slot")
+	}
+}
+
+func TestGroup_Acquire_ClosedOnUnregisterAndClose(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser(), WithPoolSize[testConfig, *testResource]("res1", 2))
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	a, releaseA, _ := g.Acquire(ctx, "res1")
+	b, releaseB, _ := g.Acquire(ctx, "res1")
+	releaseA()
+
+	if err := g.Unregister(ctx, "res1"); err != nil {
+		t.Fatalf("Unregister should not return error: %v", err)
+	}
+	if !a.Closed || !b.Closed {
+		t.Error("Unregister should close all pooled instances")
+	}
+	releaseB() // 关闭后归还不应 panic
+
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	c, releaseC, _ := g.Acquire(ctx, "res1")
+	releaseC()
+	if c == a || c == b {
+		t.Error("re-registered resource should use a new pool")
+	}
+	if errs := m.Close(ctx); len(errs) != 0 {
+		t.Errorf("Close should not return errors: %v", errs)
+	}
+	if !c.Closed {
+		t.Error("Manager.Close should close pooled instances")
+	}
+}
+
+func TestGroup_Acquire_UnregisterDuringOpen(t *testing.T) {
+	opening := make(chan struct{})
+	proceed := make(chan struct{})
+	var opened, closed atomic.Int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		opened.Add(1)
+		close(opening)
+		<-proceed
+		return &testResource{Config: cfg}, nil
+	}
+	closer := func(ctx context.Context, r *testResource) error {
+		closed.Add(1)
+		r.Closed = true
+		return nil
+	}
+	m := newManager(opener, closer)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	type result struct {
+		val *testResource
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		val, _, err := g.Acquire(ctx, "res1")
+		done <- result{val, err}
+	}()
+
+	// opener 执行期间注销资源，池被关闭
+	<-opening
+	if err := g.Unregister(ctx, "res1"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	close(proceed)
+
+	r := <-done
+	if !errors.Is(r.err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", r.err)
+	}
+	if r.val != nil {
+		t.Errorf("expected nil instance, got %v", r.val)
+	}
+	if opened.Load() != 1 || closed.Load() != 1 {
+		t.Errorf("instance created during close should be closed: opened=%d closed=%d", opened.Load(), closed.Load())
+	}
+}
+
+// ============== Close 取消测试 ==============
+
+func TestManager_Close_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var closed int32
+	closer := func(ctx context.Context, r *testResource) error {
+		atomic.AddInt32(&closed, 1)
+		r.Closed = true
+		cancel() // 第一个资源关闭后取消 ctx
+		return nil
+	}
+	m := newManager(newTestOpener(), closer)
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("res%d", i)
+		g.Register(ctx, name, testConfig{Name: name})
+		g.Get(ctx, name)
+	}
+
+	errs := m.Close(ctx)
+	if n := atomic.LoadInt32(&closed); n != 1 {
+		t.Errorf("expected exactly 1 closer call, got %d", n)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if !errors.Is(errs[0], ErrCloseInterrupted) || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("expected ErrCloseInterrupted wrapping context.Canceled, got %v", errs[0])
+	}
+
+	// 未关闭的资源应保留在组中
+	remaining := g.List()
+	if len(remaining) != 2 {
+		t.Fatalf("expected 2 remaining resources, got %v", remaining)
+	}
+	for _, name := range remaining {
+		res, err := g.Get(context.Background(), name)
+		if err != nil {
+			t.Fatalf("remaining resource %s should still be available: %v", name, err)
+		}
+		if res.Closed {
+			t.Errorf("remaining resource %s should not be closed", name)
+		}
+	}
+
+	// 使用新的 ctx 再次 Close 应完成剩余的关闭
+	if errs := m.Close(context.Background()); len(errs) != 0 {
+		t.Errorf("Close should not return errors: %v", errs)
+	}
+	if n := atomic.LoadInt32(&closed); n != 3 {
+		t.Errorf("expected 3 closer calls in total, got %d", n)
+	}
+	if len(m.ListGroupNames()) != 0 {
+		t.Error("groups should be empty after Close")
+	}
+}
+
+func TestGroup_Close_ContextCancelled(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	res, _ := g.Get(ctx, "res1")
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	errs := g.Close(cctx)
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Fatalf("expected a single context.Canceled error, got %v", errs)
+	}
+	if res.Closed {
+		t.Error("resource should not be closed after cancellation")
+	}
+	if _, err := m.Group("group1"); err != nil {
+		t.Errorf("group should remain after interrupted Close: %v", err)
+	}
+	if list := g.List(); len(list) != 1 {
+		t.Errorf("expected resource to remain registered, got %v", list)
+	}
+}
+
+// ============== GroupSummaries 测试 ==============
+
+func TestManager_GroupSummaries(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	if s := m.GroupSummaries(); len(s) != 0 {
+		t.Errorf("expected no summaries for empty manager, got %v", s)
+	}
+
+	m.AddGroup("b")
+	m.AddGroup("a")
+	m.AddGroup("empty")
+
+	ga, _ := m.Group("a")
+	ga.Register(ctx, "res1", testConfig{Name: "res1"})
+	ga.Register(ctx, "res2", testConfig{Name: "res2"})
+	ga.Register(ctx, "res3", testConfig{Name: "res3"})
+	ga.Get(ctx, "res1")
+	ga.Get(ctx, "res2")
+
+	gb, _ := m.Group("b")
+	gb.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	expected := []GroupSummary{
+		{Name: "a", Total: 3, Ready: 2},
+		{Name: "b", Total: 1, Ready: 0},
+		{Name: "empty", Total: 0, Ready: 0},
+	}
+	got := m.GroupSummaries()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected summary[%d] = %+v, got %+v", i, expected[i], got[i])
+		}
+	}
+
+	gb.Get(ctx, "res1")
+	if s := m.GroupSummaries(); s[1].Ready != 1 {
+		t.Errorf("expected group b to have 1 ready resource, got %+v", s[1])
+	}
+}
+
+// ============== WithConfigValidator 测试 ==============
+
+func TestGroup_Register_WithConfigValidator(t *testing.T) {
+	errEmptyName := errors.New("empty name")
+	validator := func(cfg testConfig) error {
+		if cfg.Name == "" {
+			return errEmptyName
+		}
+		return nil
+	}
+	m := newManager(newTestOpener(), newTestCloser(), WithConfigValidator[testConfig, *testResource](validator))
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	// 校验失败的配置不应被保存
+	isNew, err := g.Register(ctx, "bad", testConfig{})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	if !errors.Is(err, errEmptyName) {
+		t.Errorf("expected validator error to be wrapped, got %v", err)
+	}
+	if isNew {
+		t.Error("Register should return false for invalid config")
+	}
+	if _, err := g.Config(ctx, "bad"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("invalid config should not be stored, got %v", err)
+	}
+
+	// 校验通过的配置正常注册
+	isNew, err = g.Register(ctx, "good", testConfig{Name: "good"})
+	if err != nil {
+		t.Errorf("Register should not return error: %v", err)
+	}
+	if !isNew {
+		t.Error("Register should return true for valid config")
+	}
+}
+
+func TestGroup_Register_WithoutValidator(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	if _, err := g.Register(ctx, "res1", testConfig{}); err != nil {
+		t.Errorf("Register without validator should not fail: %v", err)
+	}
+}
+
+// ============== 标签测试 ==============
+
+func TestGroup_RegisterTagged(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	tags := map[string]string{"region": "us", "role": "primary"}
+	isNew, err := g.RegisterTagged(ctx, "db1", testConfig{Name: "db1"}, tags)
+	if err != nil || !isNew {
+		t.Fatalf("RegisterTagged should succeed, got isNew=%v err=%v", isNew, err)
+	}
+	g.RegisterTagged(ctx, "db2", testConfig{Name: "db2"}, map[string]string{"region": "us", "role": "replica"})
+	g.RegisterTagged(ctx, "db3", testConfig{Name: "db3"}, map[string]string{"region": "eu"})
+	g.Register(ctx, "db4", testConfig{Name: "db4"})
+
+	// 修改传入的 map 不影响已保存的标签
+	tags["region"] = "changed"
+
+	got, err := g.Tags("db1")
+	if err != nil {
+		t.Fatalf("Tags should not return error: %v", err)
+	}
+	if got["region"] != "us" || got["role"] != "primary" {
+		t.Errorf("unexpected tags: %v", got)
+	}
+
+	// 修改返回的 map 不影响已保存的标签
+	got["role"] = "changed"
+	if again, _ := g.Tags("db1"); again["role"] != "primary" {
+		t.Errorf("Tags should return a copy, got %v", again)
+	}
+
+	if got, err := g.Tags("db4"); err != nil || len(got) != 0 {
+		t.Errorf("expected empty tags for untagged resource, got %v, %v", got, err)
+	}
+	if _, err := g.Tags("nonexistent"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+}
+
+func TestGroup_FindByTag(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.RegisterTagged(ctx, "db1", testConfig{Name: "db1"}, map[string]string{"region": "us"})
+	g.RegisterTagged(ctx, "db2", testConfig{Name: "db2"}, map[string]string{"region": "us", "role": "replica"})
+	g.RegisterTagged(ctx, "db3", testConfig{Name: "db3"}, map[string]string{"region": "eu"})
+	g.Register(ctx, "db4", testConfig{Name: "db4"})
+
+	names := g.FindByTag("region", "us")
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "db1" || names[1] != "db2" {
+		t.Errorf("expected [db1 db2], got %v", names)
+	}
+
+	if names := g.FindByTag("role", "replica"); len(names) != 1 || names[0] != "db2" {
+		t.Errorf("expected [db2], got %v", names)
+	}
+
+	// 未打标签的资源不会匹配空值查询
+	if names := g.FindByTag("role", ""); len(names) != 0 {
+		t.Errorf("untagged resources should not match, got %v", names)
+	}
+	if names := g.FindByTag("region", "ap"); len(names) != 0 {
+		t.Errorf("expected no match, got %v", names)
+	}
+}
+
+// ============== GetFirstAvailable 测试 ==============
+
+func TestGroup_GetFirstAvailable(t *testing.T) {
+	errPrimaryDown := errors.New("primary down")
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Name == "primary" {
+			return nil, errPrimaryDown
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "primary", testConfig{Name: "primary"})
+	g.Register(ctx, "secondary", testConfig{Name: "secondary"})
+	g.Register(ctx, "tertiary", testConfig{Name: "tertiary"})
+
+	name, res, err := g.GetFirstAvailable(ctx, "primary", "missing", "secondary", "tertiary")
+	if err != nil {
+		t.Fatalf("GetFirstAvailable should not return error: %v", err)
+	}
+	if name != "secondary" {
+		t.Errorf("expected secondary to win, got %s", name)
+	}
+	if res == nil || res.Config.Name != "secondary" {
+		t.Errorf("expected secondary resource, got %+v", res)
+	}
+}
+
+func TestGroup_GetFirstAvailable_AllFail(t *testing.T) {
+	m := newManager(newFailingOpener("open failed"), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "primary", testConfig{Name: "primary"})
+	g.Register(ctx, "secondary", testConfig{Name: "secondary"})
+
+	name, res, err := g.GetFirstAvailable(ctx, "primary", "missing", "secondary")
+	if err == nil {
+		t.Fatal("GetFirstAvailable should return error when all fail")
+	}
+	if name != "" || res != nil {
+		t.Errorf("expected zero values, got (%q, %v)", name, res)
+	}
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected joined error to include ErrResourceNotFound, got %v", err)
+	}
+	if n := strings.Count(err.Error(), "open failed"); n != 2 {
+		t.Errorf("expected 2 opener errors in joined error, got %d: %v", n, err)
+	}
+
+	if _, _, err := g.GetFirstAvailable(ctx); !errors.Is(err, ErrNoResources) {
+		t.Errorf("expected ErrNoResources for empty names, got %v", err)
+	}
+}
+
+// ============== OnReadyChange 测试 ==============
+
+func TestGroup_OnReadyChange(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+
+	var events []bool
+	var other int
+	g.OnReadyChange("res1", func(ready bool) {
+		// 回调在锁外执行，可以安全访问注册表
+		g.List()
+		events = append(events, ready)
+	})
+	unsubscribe := g.OnReadyChange("res1", func(bool) { other++ })
+
+	g.Get(ctx, "res1")
+	g.Get(ctx, "res1") // 已就绪，不再触发
+	g.Get(ctx, "res2") // 其他资源不触发
+
+	if len(events) != 1 || !events[0] {
+		t.Fatalf("expected [true] after first Get, got %v", events)
+	}
+	if other != 1 {
+		t.Errorf("expected second callback to fire once, got %d", other)
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	if err := g.Unregister(ctx, "res1"); err != nil {
+		t.Fatalf("Unregister should not return error: %v", err)
+	}
+	if len(events) != 2 || events[1] {
+		t.Fatalf("expected [true false] after Unregister, got %v", events)
+	}
+	if other != 1 {
+		t.Errorf("unsubscribed callback should not fire, got %d calls", other)
+	}
+}
+
+func TestGroup_OnReadyChange_CloseAndOpenerError(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if fail.Load() {
+			return nil, errors.New("open failed")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	var events []bool
+	g.OnReadyChange("res1", func(ready bool) { events = append(events, ready) })
+
+	// opener 失败不触发回调
+	g.Get(ctx, "res1")
+	if len(events) != 0 {
+		t.Fatalf("failed Get should not fire callback, got %v", events)
+	}
+
+	fail.Store(false)
+	g.Get(ctx, "res1")
+	m.Close(ctx)
+	if len(events) != 2 || !events[0] || events[1] {
+		t.Errorf("expected [true false], got %v", events)
+	}
+}
+
+// ============== WarmupAll 测试 ==============
+
+func TestManager_WarmupAll_BoundedConcurrency(t *testing.T) {
+	const concurrency = 3
+	var inFlight, maxInFlight int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			old := atomic.LoadInt32(&maxInFlight)
+			if cur <= old || atomic.CompareAndSwapInt32(&maxInFlight, old, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if cfg.Value < 0 {
+			return nil, errors.New("open failed")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+
+	for _, groupName := range []string{"group1", "group2"} {
+		m.AddGroup(groupName)
+		g, _ := m.Group(groupName)
+		for i := 0; i < 10; i++ {
+			name := fmt.Sprintf("res%d", i)
+			g.Register(ctx, name, testConfig{Name: name, Value: i})
+		}
+	}
+	g2, _ := m.Group("group2")
+	g2.Register(ctx, "bad", testConfig{Name: "bad", Value: -1})
+
+	results := m.WarmupAll(ctx, concurrency)
+
+	if n := atomic.LoadInt32(&maxInFlight); n > concurrency {
+		t.Errorf("expected at most %d concurrent opens, got %d", concurrency, n)
+	}
+	if len(results) != 1 || len(results["group2"]) != 1 || results["group2"]["bad"] == nil {
+		t.Errorf("expected only group2/bad to fail, got %v", results)
+	}
+	if err := results["group2"]["bad"]; !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected ErrOpenResourceFailed, got %v", err)
+	}
+	for _, s := range m.GroupSummaries() {
+		if s.Name == "group1" && s.Ready != 10 {
+			t.Errorf("expected all group1 resources ready, got %+v", s)
+		}
+		if s.Name == "group2" && s.Ready != 10 {
+			t.Errorf("expected 10 ready resources in group2, got %+v", s)
+		}
+	}
+}
+
+func TestManager_WarmupAll_ContextCancelled(t *testing.T) {
+	var opens int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		atomic.AddInt32(&opens, 1)
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(context.Background(), "res1", testConfig{Name: "res1"})
+	g.Register(context.Background(), "res2", testConfig{Name: "res2"})
+
+	results := m.WarmupAll(ctx, 1)
+	if n := atomic.LoadInt32(&opens); n > 1 {
+		t.Errorf("expected cancellation to stop dispatching, got %d opens", n)
+	}
+	for _, name := range []string{"res1", "res2"} {
+		if err, ok := results["group1"][name]; ok && !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled for %s, got %v", name, err)
+		}
+	}
+	if len(results["group1"])+int(atomic.LoadInt32(&opens)) != 2 {
+		t.Errorf("every resource should be either opened or reported, got %v", results)
+	}
+}
+
+func TestManager_WarmupAll_ConcurrentGetOpensOnce(t *testing.T) {
+	var opens atomic.Int32
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if opens.Add(1) == 1 {
+			close(entered)
+			<-release
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	warmed := make(chan map[string]map[string]error)
+	go func() { warmed <- m.WarmupAll(ctx, 1) }()
+	<-entered
+
+	got := make(chan *testResource)
+	go func() {
+		r, err := g.Get(ctx, "res1")
+		if err != nil {
+			t.Errorf("Get failed: %v", err)
+		}
+		got <- r
+	}()
+	// 让 Get 有机会进入等待；即使 Get 晚于预热完成才执行，断言依然成立
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if results := <-warmed; len(results) != 0 {
+		t.Fatalf("unexpected warmup failures: %v", results)
+	}
+	r := <-got
+	if n := opens.Load(); n != 1 {
+		t.Errorf("expected a single opener call, got %d", n)
+	}
+	if again, _ := g.Get(ctx, "res1"); again != r || r.Closed {
+		t.Error("Get should return the instance installed by WarmupAll")
+	}
+}
+
+func TestManager_WarmupAll_GetCancelledWhileWarming(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		close(entered)
+		<-release
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(context.Background(), "res1", testConfig{Name: "res1"})
+
+	warmed := make(chan struct{})
+	go func() {
+		m.WarmupAll(context.Background(), 1)
+		close(warmed)
+	}()
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.Get(ctx, "res1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Get to stop waiting when ctx is done, got %v", err)
+	}
+	close(release)
+	<-warmed
+}
+
+func TestWithOpenerMiddleware_Order(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+	middleware := func(label string) func(Opener[testConfig, *testResource]) Opener[testConfig, *testResource] {
+		return func(next Opener[testConfig, *testResource]) Opener[testConfig, *testResource] {
+			return func(ctx context.Context, cfg testConfig) (*testResource, error) {
+				record(label + ":before")
+				res, err := next(ctx, cfg)
+				record(label + ":after")
+				return res, err
+			}
+		}
+	}
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		record("opener")
+		return &testResource{Config: cfg}, nil
+	}
+
+	m := newManager(opener, newTestCloser(),
+		WithOpenerMiddleware(middleware("outer")),
+		WithOpenerMiddleware(middleware("inner")),
+	)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1", Value: 7})
+
+	res, err := g.Get(ctx, "res1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if res.Config.Value != 7 {
+		t.Errorf("expected resource created by final opener, got %+v", res.Config)
+	}
+
+	want := []string{"outer:before", "inner:before", "opener", "inner:after", "outer:after"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("expected order %v, got %v", want, order)
+	}
+}
+
+func TestWithOpenerMiddleware_ShortCircuit(t *testing.T) {
+	var called atomic.Bool
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		called.Store(true)
+		return &testResource{Config: cfg}, nil
+	}
+	deny := func(next Opener[testConfig, *testResource]) Opener[testConfig, *testResource] {
+		return func(ctx context.Context, cfg testConfig) (*testResource, error) {
+			return nil, errors.New("denied")
+		}
+	}
+
+	m := newManager(opener, newTestCloser(), WithOpenerMiddleware(deny))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	if _, err := g.Get(ctx, "res1"); !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected ErrOpenResourceFailed, got %v", err)
+	}
+	if called.Load() {
+		t.Error("underlying opener should not be called when middleware short-circuits")
+	}
+}
+
+func TestManager_ManagerStats(t *testing.T) {
+	var fail atomic.Bool
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if fail.Load() {
+			return nil, errors.New("open failed")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+
+	if s := m.ManagerStats(); s != (ManagerStats{}) {
+		t.Errorf("expected zero stats for empty manager, got %+v", s)
+	}
+
+	m.AddGroup("group1")
+	m.AddGroup("group2")
+	g1, _ := m.Group("group1")
+	g2, _ := m.Group("group2")
+	g1.Register(ctx, "res1", testConfig{Name: "res1"})
+	g1.Register(ctx, "res2", testConfig{Name: "res2"})
+	g2.Register(ctx, "res3", testConfig{Name: "res3"})
+
+	if _, err := g1.Get(ctx, "res1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := g2.Get(ctx, "res3"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	// 已初始化的资源再次获取不应计入 opener 调用次数
+	g1.Get(ctx, "res1")
+
+	fail.Store(true)
+	if _, err := g1.Get(ctx, "res2"); err == nil {
+		t.Fatal("expected Get to fail")
+	}
+
+	want := ManagerStats{Groups: 2, Registered: 3, Ready: 2, OpenSuccesses: 2, OpenFailures: 1}
+	if s := m.ManagerStats(); s != want {
+		t.Errorf("expected %+v, got %+v", want, s)
+	}
+
+	m.Close(ctx)
+	s := m.ManagerStats()
+	if s.Groups != 0 || s.Registered != 0 || s.Ready != 0 {
+		t.Errorf("expected empty counts after Close, got %+v", s)
+	}
+	if s.OpenSuccesses != 2 || s.OpenFailures != 1 {
+		t.Errorf("expected cumulative open counters to survive Close, got %+v", s)
+	}
+}
+
+func TestGroup_CloseResources(t *testing.T) {
+	var opens atomic.Int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		opens.Add(1)
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+	g.Register(ctx, "res2", testConfig{Name: "res2", Value: 2})
+
+	first, _ := g.Get(ctx, "res1")
+
+	if errs := g.CloseResources(ctx); len(errs) != 0 {
+		t.Fatalf("CloseResources returned errors: %v", errs)
+	}
+	if !first.Closed {
+		t.Error("ready resource should be closed")
+	}
+
+	// 组和配置应保留
+	if _, err := m.Group("group1"); err != nil {
+		t.Fatalf("group should survive CloseResources: %v", err)
+	}
+	names := g.List()
+	sort.Strings(names)
+	if strings.Join(names, ",") != "res1,res2" {
+		t.Errorf("expected configs to survive, got %v", names)
+	}
+	if s := m.GroupSummaries(); len(s) != 1 || s[0].Ready != 0 {
+		t.Errorf("expected no ready resources after CloseResources, got %+v", s)
+	}
+
+	// 再次 Get 应重新创建资源
+	second, err := g.Get(ctx, "res1")
+	if err != nil {
+		t.Fatalf("Get after CloseResources failed: %v", err)
+	}
+	if second == first || second.Closed {
+		t.Error("Get after CloseResources should rebuild a fresh resource")
+	}
+	if second.Config.Value != 1 {
+		t.Errorf("expected rebuilt resource to use saved config, got %+v", second.Config)
+	}
+	if n := opens.Load(); n != 2 {
+		t.Errorf("expected opener to be called twice, got %d", n)
+	}
+}
+
+func TestGroup_CloseResources_CloserError(t *testing.T) {
+	m := newManager(newTestOpener(), newFailingCloser("close failed"))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Get(ctx, "res1")
+
+	errs := g.CloseResources(ctx)
+	if len(errs) != 1 || !errors.Is(errs[0], ErrCloseResourceFailed) {
+		t.Errorf("expected one ErrCloseResourceFailed, got %v", errs)
+	}
+	if _, err := g.Config(ctx, "res1"); err != nil {
+		t.Errorf("config should survive closer failure: %v", err)
+	}
+}
+
+func TestResourceIdentityFromContext(t *testing.T) {
+	type identity struct{ group, name string }
+	var (
+		mu     sync.Mutex
+		opened []identity
+		closed []identity
+	)
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		group, ok1 := GroupFromContext(ctx)
+		name, ok2 := NameFromContext(ctx)
+		if !ok1 || !ok2 {
+			return nil, errors.New("missing identity in ctx")
+		}
+		mu.Lock()
+		opened = append(opened, identity{group, name})
+		mu.Unlock()
+		return &testResource{Config: cfg}, nil
+	}
+	closer := func(ctx context.Context, r *testResource) error {
+		group, _ := GroupFromContext(ctx)
+		name, _ := NameFromContext(ctx)
+		mu.Lock()
+		closed = append(closed, identity{group, name})
+		mu.Unlock()
+		return nil
+	}
+
+	m := newManager(opener, closer)
+	ctx := context.Background()
+	m.AddGroup("db")
+	g, _ := m.Group("db")
+	g.Register(ctx, "master", testConfig{Name: "master"})
+
+	if _, err := g.Get(ctx, "master"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(opened) != 1 || opened[0] != (identity{"db", "master"}) {
+		t.Errorf("expected opener to see db/master, got %v", opened)
+	}
+
+	if err := g.Unregister(ctx, "master"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if len(closed) != 1 || closed[0] != (identity{"db", "master"}) {
+		t.Errorf("expected closer to see db/master, got %v", closed)
+	}
+
+	// 调用方的 ctx 不携带资源标识
+	if _, ok := GroupFromContext(ctx); ok {
+		t.Error("GroupFromContext should report false outside opener")
+	}
+	if _, ok := NameFromContext(ctx); ok {
+		t.Error("NameFromContext should report false outside opener")
+	}
+}
+
+func TestResourceKey_Exported(t *testing.T) {
+	var seen ResourceIdentity
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		id, ok := ctx.Value(ResourceKey{}).(ResourceIdentity)
+		if !ok {
+			return nil, errors.New("missing identity in ctx")
+		}
+		seen = id
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("db")
+	g, _ := m.Group("db")
+	g.Register(ctx, "master", testConfig{Name: "master"})
+	if _, err := g.Get(ctx, "master"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if seen != (ResourceIdentity{Group: "db", Name: "master"}) {
+		t.Errorf("expected db/master via ResourceKey, got %+v", seen)
+	}
+
+	// 手动构造的 ctx 同样可以被辅助函数读取
+	manual := context.WithValue(ctx, ResourceKey{}, ResourceIdentity{Group: "cache", Name: "redis"})
+	if group, ok := GroupFromContext(manual); !ok || group != "cache" {
+		t.Errorf("expected cache, got %q (%v)", group, ok)
+	}
+	if name, ok := NameFromContext(manual); !ok || name != "redis" {
+		t.Errorf("expected redis, got %q (%v)", name, ok)
+	}
+}
+
+func TestGroup_UnregisterWhere(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "keep1", testConfig{Name: "keep1", Value: 1})
+	g.Register(ctx, "drop1", testConfig{Name: "drop1", Value: -1})
+	g.Register(ctx, "drop2", testConfig{Name: "drop2", Value: -2})
+	g.Register(ctx, "keep2", testConfig{Name: "keep2", Value: 2})
+
+	dropped, _ := g.Get(ctx, "drop1")
+	kept, _ := g.Get(ctx, "keep1")
+
+	errs := g.UnregisterWhere(ctx, func(name string, cfg testConfig) bool {
+		return cfg.Value < 0
+	})
+	if len(errs) != 0 {
+		t.Fatalf("UnregisterWhere returned errors: %v", errs)
+	}
+
+	names := g.List()
+	sort.Strings(names)
+	if strings.Join(names, ",") != "keep1,keep2" {
+		t.Errorf("expected only non-matching resources to remain, got %v", names)
+	}
+	if !dropped.Closed {
+		t.Error("matching ready resource should be closed")
+	}
+	if kept.Closed {
+		t.Error("non-matching resource should not be closed")
+	}
+	if _, err := g.Get(ctx, "drop2"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound for removed resource, got %v", err)
+	}
+}
+
+func TestGroup_UnregisterWhere_CloserError(t *testing.T) {
+	m := newManager(newTestOpener(), newFailingCloser("close failed"))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+	g.Get(ctx, "res1")
+
+	errs := g.UnregisterWhere(ctx, func(name string, cfg testConfig) bool { return true })
+	if len(errs) != 1 || !errors.Is(errs[0], ErrCloseResourceFailed) {
+		t.Errorf("expected one ErrCloseResourceFailed, got %v", errs)
+	}
+	if names := g.List(); len(names) != 0 {
+		t.Errorf("expected all resources removed despite closer error, got %v", names)
+	}
+}
+
+func TestGroup_PingAny_OneHealthy(t *testing.T) {
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Value != 1 {
+			return nil, errors.New("unreachable")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "bad1", testConfig{Name: "bad1"})
+	g.Register(ctx, "good", testConfig{Name: "good", Value: 1})
+	g.Register(ctx, "bad2", testConfig{Name: "bad2"})
+	g.Register(ctx, "bad3", testConfig{Name: "bad3"})
+
+	if err := g.PingAny(ctx); err != nil {
+		t.Errorf("PingAny should succeed when one resource is healthy, got %v", err)
+	}
+	if s := m.GroupSummaries(); s[0].Ready != 0 {
+		t.Errorf("PingAny should not initialize resources, got %+v", s)
+	}
+}
+
+func TestGroup_PingAny_AllFail(t *testing.T) {
+	m := newManager(newFailingOpener("unreachable"), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+
+	err := g.PingAny(ctx)
+	if !errors.Is(err, ErrPingResourceFailed) {
+		t.Fatalf("expected ErrPingResourceFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"res1"`) || !strings.Contains(err.Error(), `"res2"`) {
+		t.Errorf("expected both failures to be joined, got %v", err)
+	}
+}
+
+func TestGroup_PingAny_EmptyAndMissingGroup(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	if err := g.PingAny(ctx); !errors.Is(err, ErrNoResources) {
+		t.Errorf("expected ErrNoResources for empty group, got %v", err)
+	}
+	m.Close(ctx)
+	if err := g.PingAny(ctx); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func TestGroup_PingAny_ContextCancelled(t *testing.T) {
+	var calls atomic.Int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		calls.Add(1)
+		return nil, errors.New("unreachable")
+	}
+	m := newManager(opener, newTestCloser())
+	ctx, cancel := context.WithCancel(context.Background())
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+	cancel()
+
+	err := g.PingAny(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("expected no opener calls after cancellation, got %d", n)
+	}
+}
+
+func TestGroup_GetByKey_Stable(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("shards")
+	g, _ := m.Group("shards")
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("shard%d", i)
+		g.Register(ctx, name, testConfig{Name: name})
+	}
+
+	used := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		name, res, err := g.GetByKey(ctx, key)
+		if err != nil {
+			t.Fatalf("GetByKey failed: %v", err)
+		}
+		if res.Config.Name != name {
+			t.Errorf("returned resource %q does not match name %q", res.Config.Name, name)
+		}
+		for j := 0; j < 3; j++ {
+			if again, _, _ := g.GetByKey(ctx, key); again != name {
+				t.Fatalf("key %q mapped to %q then %q", key, name, again)
+			}
+		}
+		used[name] = true
+	}
+	if len(used) < 2 {
+		t.Errorf("expected keys to spread across shards, got %v", used)
+	}
+}
+
+func TestGroup_GetByKey_RemoveUnrelated(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("shards")
+	g, _ := m.Group("shards")
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("shard%d", i)
+		g.Register(ctx, name, testConfig{Name: name})
+	}
+
+	before := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		name, _, _ := g.GetByKey(ctx, key)
+		before[key] = name
+	}
+
+	const removed = "shard2"
+	g.Unregister(ctx, removed)
+
+	for key, name := range before {
+		after, _, err := g.GetByKey(ctx, key)
+		if err != nil {
+			t.Fatalf("GetByKey failed: %v", err)
+		}
+		if name != removed && after != name {
+			t.Errorf("key %q remapped from %q to %q after removing unrelated %q", key, name, after, removed)
+		}
+		if after == removed {
+			t.Errorf("key %q still mapped to removed resource", key)
+		}
+	}
+}
+
+func TestGroup_GetByKey_Empty(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("shards")
+	g, _ := m.Group("shards")
+
+	if _, _, err := g.GetByKey(ctx, "user-1"); !errors.Is(err, ErrNoResources) {
+		t.Errorf("expected ErrNoResources, got %v", err)
+	} else if !strings.Contains(err.Error(), `"shards"`) {
+		t.Errorf("expected error to name the group, got %v", err)
+	}
+}
+
+func TestGroup_ForEachConcurrent_BoundedConcurrency(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("res%d", i)
+		g.Register(ctx, name, testConfig{Name: name})
+		g.Get(ctx, name)
+	}
+	g.Register(ctx, "lazy", testConfig{Name: "lazy"})
+
+	var (
+		inFlight, maxInFlight atomic.Int32
+		mu                    sync.Mutex
+		visited               []string
+	)
+	err := g.ForEachConcurrent(ctx, 3, func(ctx context.Context, name string, res *testResource) error {
+		n := inFlight.Add(1)
+		for {
+			cur := maxInFlight.Load()
+			if n <= cur || maxInFlight.CompareAndSwap(cur, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+
+		mu.Lock()
+		visited = append(visited, name)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachConcurrent failed: %v", err)
+	}
+	if n := maxInFlight.Load(); n > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", n)
+	}
+	if len(visited) != 10 {
+		t.Errorf("expected 10 ready resources visited (lazy one skipped), got %d: %v", len(visited), visited)
+	}
+}
+
+func TestGroup_ForEachConcurrent_FirstErrorCancels(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("res%02d", i)
+		g.Register(ctx, name, testConfig{Name: name})
+		g.Get(ctx, name)
+	}
+
+	boom := errors.New("boom")
+	var (
+		calls     atomic.Int32
+		cancelled atomic.Int32
+	)
+	err := g.ForEachConcurrent(ctx, 2, func(ctx context.Context, name string, res *testResource) error {
+		calls.Add(1)
+		if name == "res00" {
+			return boom
+		}
+		select {
+		case <-ctx.Done():
+			cancelled.Add(1)
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected first error to be returned, got %v", err)
+	}
+	if n := calls.Load(); n >= 20 {
+		t.Errorf("expected remaining work to be skipped after the error, got %d calls", n)
+	}
+	if calls.Load() > 1 && cancelled.Load() == 0 {
+		t.Error("expected in-flight calls to observe cancellation")
+	}
+}
+
+func TestGroup_ForEachConcurrent_GroupNotFound(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	m.Close(ctx)
+
+	err := g.ForEachConcurrent(ctx, 2, func(ctx context.Context, name string, res *testResource) error { return nil })
+	if !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func TestGroup_Reset(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser(), WithStrictGroups[testConfig, *testResource]())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+	res1, _ := g.Get(ctx, "res1")
+
+	if errs := g.Reset(ctx); len(errs) != 0 {
+		t.Fatalf("Reset returned errors: %v", errs)
+	}
+	if !res1.Closed {
+		t.Error("ready resource should be closed by Reset")
+	}
+	if names := g.List(); len(names) != 0 {
+		t.Errorf("expected empty List after Reset, got %v", names)
+	}
+	if _, err := m.Group("group1"); err != nil {
+		t.Errorf("group should still exist after Reset: %v", err)
+	}
+
+	// 严格模式下组仍存在，可直接重新注册
+	if _, err := g.Register(ctx, "res3", testConfig{Name: "res3"}); err != nil {
+		t.Errorf("Register after Reset failed: %v", err)
+	}
+}
+
+func TestGroup_Reset_CloserError(t *testing.T) {
+	m := newManager(newTestOpener(), newFailingCloser("close failed"))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Get(ctx, "res1")
+
+	errs := g.Reset(ctx)
+	if len(errs) != 1 || !errors.Is(errs[0], ErrCloseResourceFailed) {
+		t.Errorf("expected one ErrCloseResourceFailed, got %v", errs)
+	}
+	if names := g.List(); len(names) != 0 {
+		t.Errorf("expected empty List after Reset, got %v", names)
+	}
+}
+
+func TestGroup_GetWithDeps(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		mu.Lock()
+		order = append(order, cfg.Name)
+		mu.Unlock()
+		return &testResource{Config: cfg}, nil
+	}
+	var gotDeps map[string]*testResource
+	depOpener := func(ctx context.Context, cfg testConfig, deps map[string]*testResource) (*testResource, error) {
+		mu.Lock()
+		order = append(order, cfg.Name)
+		mu.Unlock()
+		gotDeps = deps
+		return &testResource{Config: testConfig{Name: cfg.Name, Value: deps["old"].Config.Value + deps["new"].Config.Value}}, nil
+	}
+
+	m := newManager(opener, newTestCloser(), WithDependentOpener(depOpener))
+	ctx := context.Background()
+	m.AddGroup("db")
+	g, _ := m.Group("db")
+	g.Register(ctx, "old", testConfig{Name: "old", Value: 1})
+	g.Register(ctx, "new", testConfig{Name: "new", Value: 2})
+	g.Register(ctx, "tee", testConfig{Name: "tee"})
+
+	tee, err := g.GetWithDeps(ctx, "tee", "old", "new")
+	if err != nil {
+		t.Fatalf("GetWithDeps failed: %v", err)
+	}
+	if strings.Join(order, ",") != "old,new,tee" {
+		t.Errorf("expected dependencies to init first, got %v", order)
+	}
+	if tee.Config.Value != 3 {
+		t.Errorf("expected dependent opener to build from deps, got %+v", tee.Config)
+	}
+	oldRes, _ := g.Get(ctx, "old")
+	if gotDeps["old"] != oldRes {
+		t.Error("expected deps to contain the shared dependency instance")
+	}
+
+	// 已初始化后再次调用直接返回缓存的实例
+	again, _ := g.GetWithDeps(ctx, "tee", "old", "new")
+	if again != tee || len(order) != 3 {
+		t.Errorf("expected cached resource without reopening, order=%v", order)
+	}
+}
+
+func TestGroup_GetWithDeps_DependencyFailure(t *testing.T) {
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Name == "broken" {
+			return nil, errors.New("dial failed")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	var depCalls atomic.Int32
+	depOpener := func(ctx context.Context, cfg testConfig, deps map[string]*testResource) (*testResource, error) {
+		depCalls.Add(1)
+		return &testResource{Config: cfg}, nil
+	}
+
+	m := newManager(opener, newTestCloser(), WithDependentOpener(depOpener))
+	ctx := context.Background()
+	m.AddGroup("db")
+	g, _ := m.Group("db")
+	g.Register(ctx, "ok", testConfig{Name: "ok"})
+	g.Register(ctx, "broken", testConfig{Name: "broken"})
+	g.Register(ctx, "tee", testConfig{Name: "tee"})
+
+	_, err := g.GetWithDeps(ctx, "tee", "ok", "broken")
+	if !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected dependency error to be returned, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `dependency "broken"`) {
+		t.Errorf("expected error to name the failing dependency, got %v", err)
+	}
+	if n := depCalls.Load(); n != 0 {
+		t.Errorf("dependent opener should not run when a dependency fails, got %d calls", n)
+	}
+	if _, err := g.GetWithDeps(ctx, "tee", "missing"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound for unregistered dependency, got %v", err)
+	}
+}
+
+func TestManager_UnregisterEverywhere(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	for _, name := range []string{"shard1", "shard2", "shard3"} {
+		m.AddGroup(name)
+	}
+	g1, _ := m.Group("shard1")
+	g2, _ := m.Group("shard2")
+	g3, _ := m.Group("shard3")
+	g1.Register(ctx, "primary", testConfig{Name: "primary"})
+	g1.Register(ctx, "replica", testConfig{Name: "replica"})
+	g2.Register(ctx, "primary", testConfig{Name: "primary"})
+	g3.Register(ctx, "replica", testConfig{Name: "replica"})
+	p1, _ := g1.Get(ctx, "primary")
+
+	errs := m.UnregisterEverywhere(ctx, "primary")
+	if errs == nil || len(errs) != 0 {
+		t.Errorf("expected non-nil empty error map, got %v", errs)
+	}
+	if !p1.Closed {
+		t.Error("ready resource should be closed")
+	}
+	for _, g := range []Group[testConfig, *testResource]{g1, g2, g3} {
+		if _, err := g.Config(ctx, "primary"); !errors.Is(err, ErrResourceNotFound) {
+			t.Errorf("expected primary to be removed everywhere, got %v", err)
+		}
+	}
+	if names := g1.List(); len(names) != 1 || names[0] != "replica" {
+		t.Errorf("other resources should remain, got %v", names)
+	}
+	if names := g3.List(); len(names) != 1 {
+		t.Errorf("group without the name should be untouched, got %v", names)
+	}
+}
+
+func TestManager_UnregisterEverywhere_CloserError(t *testing.T) {
+	m := newManager(newTestOpener(), newFailingCloser("close failed"))
+	ctx := context.Background()
+	for _, name := range []string{"shard1", "shard2", "shard3"} {
+		m.AddGroup(name)
+	}
+	g1, _ := m.Group("shard1")
+	g2, _ := m.Group("shard2")
+	g1.Register(ctx, "primary", testConfig{Name: "primary"})
+	g2.Register(ctx, "primary", testConfig{Name: "primary"})
+	g1.Get(ctx, "primary")
+
+	errs := m.UnregisterEverywhere(ctx, "primary")
+	if len(errs) != 1 {
+		t.Fatalf("expected only the ready resource to report an error, got %v", errs)
+	}
+	if !errors.Is(errs["shard1"], ErrCloseResourceFailed) {
+		t.Errorf("expected ErrCloseResourceFailed for shard1, got %v", errs["shard1"])
+	}
+	if names := g1.List(); len(names) != 0 {
+		t.Errorf("resource should be removed despite closer error, got %v", names)
+	}
+}
+
+func TestGroup_OpenCount_SingleFlight(t *testing.T) {
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		time.Sleep(10 * time.Millisecond)
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	if n := g.OpenCount("res1"); n != 0 {
+		t.Errorf("expected OpenCount 0 before Get, got %d", n)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Get(ctx, "res1")
+		}()
+	}
+	wg.Wait()
+
+	if n := g.OpenCount("res1"); n != 1 {
+		t.Errorf("expected opener to run once under contention, got %d", n)
+	}
+	if n := g.OpenCount("missing"); n != 0 {
+		t.Errorf("expected 0 for unknown resource, got %d", n)
+	}
+}
+
+func TestGroup_OpenCount_CountsFailures(t *testing.T) {
+	m := newManager(newFailingOpener("open failed"), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	g.Get(ctx, "res1")
+	g.Get(ctx, "res1")
+	if n := g.OpenCount("res1"); n != 2 {
+		t.Errorf("expected failed attempts to be counted, got %d", n)
+	}
+}
+
+func TestGroup_GetOrZero(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+
+	res := g.GetOrZero(ctx, "res1")
+	if res == nil || res.Config.Value != 1 {
+		t.Errorf("expected resource on success, got %v", res)
+	}
+	if again, _ := g.Get(ctx, "res1"); again != res {
+		t.Error("GetOrZero should return the shared instance")
+	}
+}
+
+func TestGroup_GetOrZero_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resource not found", func(t *testing.T) {
+		m := newManager(newTestOpener(), newTestCloser())
+		m.AddGroup("group1")
+		g, _ := m.Group("group1")
+		if res := g.GetOrZero(ctx, "missing"); res != nil {
+			t.Errorf("expected zero value, got %v", res)
+		}
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		m := newManager(newTestOpener(), newTestCloser())
+		m.AddGroup("group1")
+		g, _ := m.Group("group1")
+		g.Register(ctx, "res1", testConfig{Name: "res1"})
+		m.Close(ctx)
+		if res := g.GetOrZero(ctx, "res1"); res != nil {
+			t.Errorf("expected zero value, got %v", res)
+		}
+	})
+
+	t.Run("opener error", func(t *testing.T) {
+		m := newManager(newFailingOpener("open failed"), newTestCloser())
+		m.AddGroup("group1")
+		g, _ := m.Group("group1")
+		g.Register(ctx, "res1", testConfig{Name: "res1"})
+		if res := g.GetOrZero(ctx, "res1"); res != nil {
+			t.Errorf("expected zero value, got %v", res)
+		}
+	})
+
+	t.Run("context timeout", func(t *testing.T) {
+		opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		m := newManager(opener, newTestCloser())
+		m.AddGroup("group1")
+		g, _ := m.Group("group1")
+		g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+		tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if res := g.GetOrZero(tctx, "res1"); res != nil {
+			t.Errorf("expected zero value, got %v", res)
+		}
+	})
+}
+
+func TestManager_MarshalOverview(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("db")
+	m.AddGroup("cache")
+	m.AddGroup("empty")
+	db, _ := m.Group("db")
+	cache, _ := m.Group("cache")
+	db.Register(ctx, "master", testConfig{Name: "master", Value: 1})
+	db.Register(ctx, "slave", testConfig{Name: "slave", Value: 2})
+	cache.Register(ctx, "redis", testConfig{Name: "redis", Value: 3})
+	db.Get(ctx, "master")
+
+	data, err := m.MarshalOverview()
+	if err != nil {
+		t.Fatalf("MarshalOverview failed: %v", err)
+	}
+	if strings.Contains(string(data), "config") {
+		t.Errorf("configs should be omitted by default, got %s", data)
+	}
+
+	var got struct {
+		Groups map[string]struct {
+			Resources map[string]struct {
+				Ready bool `json:"ready"`
+			} `json:"resources"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(got.Groups) != 3 {
+		t.Errorf("expected 3 groups, got %d", len(got.Groups))
+	}
+	if r := got.Groups["db"].Resources; len(r) != 2 || !r["master"].Ready || r["slave"].Ready {
+		t.Errorf("unexpected db resources: %+v", r)
+	}
+	if r := got.Groups["cache"].Resources; len(r) != 1 || r["redis"].Ready {
+		t.Errorf("unexpected cache resources: %+v", r)
+	}
+	if r, ok := got.Groups["empty"]; !ok || r.Resources == nil || len(r.Resources) != 0 {
+		t.Errorf("expected empty group with empty resources object, got %+v", r)
+	}
+}
+
+func TestManager_MarshalOverviewWithConfigs(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("db")
+	db, _ := m.Group("db")
+	db.Register(ctx, "master", testConfig{Name: "master", Value: 42})
+
+	data, err := m.MarshalOverviewWithConfigs()
+	if err != nil {
+		t.Fatalf("MarshalOverviewWithConfigs failed: %v", err)
+	}
+
+	var got struct {
+		Groups map[string]struct {
+			Resources map[string]struct {
+				Ready  bool       `json:"ready"`
+				Config testConfig `json:"config"`
+			} `json:"resources"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	r := got.Groups["db"].Resources["master"]
+	if r.Ready || r.Config != (testConfig{Name: "master", Value: 42}) {
+		t.Errorf("unexpected resource overview: %+v", r)
+	}
+}
+
+func TestGroup_LastError(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if fail.Load() {
+			return nil, errors.New("connection refused")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	if _, _, ok := g.LastError("res1"); ok {
+		t.Error("expected no last error before any failure")
+	}
+
+	before := time.Now()
+	g.Get(ctx, "res1")
+	err, at, ok := g.LastError("res1")
+	if !ok {
+		t.Fatal("expected last error after failed Get")
+	}
+	if err == nil || err.Error() != "connection refused" {
+		t.Errorf("expected original opener error, got %v", err)
+	}
+	if at.Before(before) || at.After(time.Now()) {
+		t.Errorf("unexpected error timestamp %v", at)
+	}
+
+	fail.Store(false)
+	if _, err := g.Get(ctx, "res1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err, _, ok := g.LastError("res1"); ok {
+		t.Errorf("expected last error to be cleared after success, got %v", err)
+	}
+	if _, _, ok := g.LastError("missing"); ok {
+		t.Error("expected ok=false for unknown resource")
+	}
+}
+
+func TestGroup_Touch(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+
+	if _, ok := g.LastAccess("res1"); ok {
+		t.Error("expected no last access before any use")
+	}
+
+	g.Get(ctx, "res1")
+	afterGet, ok := g.LastAccess("res1")
+	if !ok {
+		t.Fatal("expected Get to record last access")
+	}
+
+	before := time.Now()
+	if err := g.Touch("res1"); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+	after := time.Now()
+	afterTouch, _ := g.LastAccess("res1")
+	if afterTouch.Before(afterGet) {
+		t.Errorf("Touch should not move last access backwards, got %v then %v", afterGet, afterTouch)
+	}
+	if afterTouch.Before(before.Truncate(0)) || afterTouch.After(after.Truncate(0)) {
+		t.Errorf("expected last access within [%v, %v], got %v", before, after, afterTouch)
+	}
+	if s := m.GroupSummaries(); s[0].Ready != 1 {
+		t.Errorf("Touch should not change ready state, got %+v", s)
+	}
+
+	// Touch 不会初始化资源，但会记录访问时间
+	if err := g.Touch("res2"); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+	if _, ok := g.LastAccess("res2"); !ok {
+		t.Error("expected Touch to record last access for uninitialized resource")
+	}
+	if s := m.GroupSummaries(); s[0].Ready != 1 {
+		t.Errorf("Touch should not initialize the resource, got %+v", s)
+	}
+}
+
+func TestGroup_Touch_NotFound(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	if err := g.Touch("missing"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+	m.Close(context.Background())
+	if err := g.Touch("missing"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func TestGroup_ComputeIfAbsent(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "existing", testConfig{Name: "existing", Value: 1})
+
+	res, err := g.ComputeIfAbsent(ctx, "existing", func() testConfig {
+		t.Error("cfgFn should not run for an existing name")
+		return testConfig{}
+	})
+	if err != nil || res.Config.Value != 1 {
+		t.Errorf("expected existing resource, got %v, %v", res, err)
+	}
+
+	res, err = g.ComputeIfAbsent(ctx, "new", func() testConfig { return testConfig{Name: "new", Value: 2} })
+	if err != nil {
+		t.Fatalf("ComputeIfAbsent failed: %v", err)
+	}
+	if res.Config.Value != 2 {
+		t.Errorf("expected resource built from computed config, got %+v", res.Config)
+	}
+	if cfg, err := g.Config(ctx, "new"); err != nil || cfg.Value != 2 {
+		t.Errorf("expected computed config to be registered, got %+v, %v", cfg, err)
+	}
+	if again, _ := g.Get(ctx, "new"); again != res {
+		t.Error("expected ComputeIfAbsent to initialize the shared instance")
+	}
+}
+
+func TestGroup_ComputeIfAbsent_Concurrent(t *testing.T) {
+	var opens, computes atomic.Int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		opens.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	var wg sync.WaitGroup
+	results := make([]*testResource, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = g.ComputeIfAbsent(ctx, "contested", func() testConfig {
+				computes.Add(1)
+				return testConfig{Name: "contested"}
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	if n := computes.Load(); n != 1 {
+		t.Errorf("expected cfgFn to run once, got %d", n)
+	}
+	if n := opens.Load(); n != 1 {
+		t.Errorf("expected opener to run once, got %d", n)
+	}
+	for i, r := range results {
+		if r == nil || r != results[0] {
+			t.Fatalf("result %d differs from shared instance", i)
+		}
+	}
+}
+
+func TestGroup_ComputeIfAbsent_Errors(t *testing.T) {
+	ctx := context.Background()
+	validator := WithConfigValidator[testConfig, *testResource](func(cfg testConfig) error {
+		if cfg.Name == "" {
+			return errors.New("empty name")
+		}
+		return nil
+	})
+	m := newManager(newTestOpener(), newTestCloser(), validator, WithStrictGroups[testConfig, *testResource]())
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	if _, err := g.ComputeIfAbsent(ctx, "bad", func() testConfig { return testConfig{} }); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	if names := g.List(); len(names) != 0 {
+		t.Errorf("invalid config should not be registered, got %v", names)
+	}
+
+	m.Close(ctx)
+	if _, err := g.ComputeIfAbsent(ctx, "res1", func() testConfig { return testConfig{Name: "res1"} }); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound in strict mode, got %v", err)
+	}
+}
+
+func TestManager_Freeze(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+
+	if m.IsFrozen() {
+		t.Fatal("manager should not be frozen initially")
+	}
+	m.Freeze()
+	if !m.IsFrozen() {
+		t.Fatal("manager should be frozen after Freeze")
+	}
+
+	if _, err := g.Register(ctx, "res3", testConfig{Name: "res3"}); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Register: expected ErrFrozen, got %v", err)
+	}
+	if _, err := g.RegisterTagged(ctx, "res3", testConfig{Name: "res3"}, nil); !errors.Is(err, ErrFrozen) {
+		t.Errorf("RegisterTagged: expected ErrFrozen, got %v", err)
+	}
+	if _, err := g.ComputeIfAbsent(ctx, "res3", func() testConfig { return testConfig{Name: "res3"} }); !errors.Is(err, ErrFrozen) {
+		t.Errorf("ComputeIfAbsent: expected ErrFrozen, got %v", err)
+	}
+	if err := g.Unregister(ctx, "res1"); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Unregister: expected ErrFrozen, got %v", err)
+	}
+	if errs := g.UnregisterWhere(ctx, func(string, testConfig) bool { return true }); len(errs) != 1 || !errors.Is(errs[0], ErrFrozen) {
+		t.Errorf("UnregisterWhere: expected ErrFrozen, got %v", errs)
+	}
+	if errs := m.UnregisterEverywhere(ctx, "res1"); !errors.Is(errs["group1"], ErrFrozen) {
+		t.Errorf("UnregisterEverywhere: expected ErrFrozen, got %v", errs)
+	}
+	if errs := g.Reset(ctx); len(errs) != 1 || !errors.Is(errs[0], ErrFrozen) {
+		t.Errorf("Reset: expected ErrFrozen, got %v", errs)
+	}
+	if m.AddGroup("group2") {
+		t.Error("AddGroup should report the group as not existing")
+	}
+	if _, err := m.Group("group2"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("AddGroup should not create groups while frozen, got %v", err)
+	}
+
+	// 读取操作不受影响
+	if _, err := g.Get(ctx, "res1"); err != nil {
+		t.Errorf("Get should still work while frozen: %v", err)
+	}
+	if res, err := g.ComputeIfAbsent(ctx, "res2", func() testConfig { return testConfig{} }); err != nil || res == nil {
+		t.Errorf("ComputeIfAbsent on existing name should work while frozen: %v", err)
+	}
+
+	// 实例的重建与关闭不改变注册内容，不受影响
+	if _, err := g.GetFresh(ctx, "res1"); err != nil {
+		t.Errorf("GetFresh should still work while frozen: %v", err)
+	}
+	if errs := g.CloseResources(ctx); len(errs) != 0 {
+		t.Errorf("CloseResources should still work while frozen: %v", errs)
+	}
+	names := g.List()
+	sort.Strings(names)
+	if strings.Join(names, ",") != "res1,res2" {
+		t.Errorf("expected registrations to be unchanged, got %v", names)
+	}
+
+	// Close 解除冻结
+	if errs := m.Close(ctx); len(errs) != 0 {
+		t.Fatalf("Close failed: %v", errs)
+	}
+	if m.IsFrozen() {
+		t.Error("Close should unfreeze the manager")
+	}
+	if _, err := g.Register(ctx, "res1", testConfig{Name: "res1"}); err != nil {
+		t.Errorf("Register after Close should succeed, got %v", err)
+	}
+}
+
+func TestManager_AddGroupE_Frozen(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	if existed, err := m.AddGroupE("group1"); existed || err != nil {
+		t.Fatalf("expected (false, nil) for new group, got (%v, %v)", existed, err)
+	}
+
+	m.Freeze()
+	if existed, err := m.AddGroupE("group1"); !existed || err != nil {
+		t.Errorf("expected (true, nil) for existing group while frozen, got (%v, %v)", existed, err)
+	}
+	existed, err := m.AddGroupE("group2")
+	if existed || !errors.Is(err, ErrFrozen) {
+		t.Errorf("expected (false, ErrFrozen), got (%v, %v)", existed, err)
+	}
+	if _, err := m.Group("group2"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("frozen manager should not create group2, got %v", err)
+	}
+}
+
+func TestGroup_View(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "ready", testConfig{Name: "ready", Value: 1})
+	g.Register(ctx, "pending", testConfig{Name: "pending", Value: 2})
+	res, _ := g.Get(ctx, "ready")
+
+	v, err := g.View("ready")
+	if err != nil {
+		t.Fatalf("View failed: %v", err)
+	}
+	if v.Name != "ready" || v.Config.Value != 1 || !v.Ready || v.Value != res {
+		t.Errorf("unexpected view of ready resource: %+v", v)
+	}
+
+	v, err = g.View("pending")
+	if err != nil {
+		t.Fatalf("View failed: %v", err)
+	}
+	if v.Name != "pending" || v.Config.Value != 2 || v.Ready || v.Value != nil {
+		t.Errorf("unexpected view of pending resource: %+v", v)
+	}
+	if s := m.GroupSummaries(); s[0].Ready != 1 {
+		t.Errorf("View should not initialize resources, got %+v", s)
+	}
+
+	if _, err := g.View("missing"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+	m.Close(ctx)
+	if _, err := g.View("ready"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func TestWithCloseOrder(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		closed []string
+	)
+	closer := func(ctx context.Context, r *testResource) error {
+		mu.Lock()
+		closed = append(closed, r.Config.Name)
+		mu.Unlock()
+		return nil
+	}
+	// 依赖关系：app -> cache -> db，按依赖方先关闭的顺序
+	rank := map[string]int{"app": 0, "cache": 1, "db": 2}
+	order := WithCloseOrder[testConfig, *testResource](func(names []string) []string {
+		sort.SliceStable(names, func(i, j int) bool { return rank[names[i]] < rank[names[j]] })
+		return names
+	})
+
+	m := newManager(newTestOpener(), closer, order)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	for _, name := range []string{"db", "cache", "app"} {
+		g.Register(ctx, name, testConfig{Name: name})
+		g.Get(ctx, name)
+	}
+
+	if errs := g.Close(ctx); len(errs) != 0 {
+		t.Fatalf("Close failed: %v", errs)
+	}
+	if strings.Join(closed, ",") != "app,cache,db" {
+		t.Errorf("expected closers to run in dependency order, got %v", closed)
+	}
+}
+
+func TestWithCloseOrder_OmittedNamesStillClosed(t *testing.T) {
+	var closed []string
+	closer := func(ctx context.Context, r *testResource) error {
+		closed = append(closed, r.Config.Name)
+		return nil
+	}
+	order := WithCloseOrder[testConfig, *testResource](func(names []string) []string {
+		return []string{"c", "unknown"}
+	})
+
+	m := newManager(newTestOpener(), closer, order)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	for _, name := range []string{"a", "b", "c"} {
+		g.Register(ctx, name, testConfig{Name: name})
+		g.Get(ctx, name)
+	}
+
+	if errs := m.Close(ctx); len(errs) != 0 {
+		t.Fatalf("Close failed: %v", errs)
+	}
+	if strings.Join(closed, ",") != "c,a,b" {
+		t.Errorf("expected listed names first and omitted names appended in order, got %v", closed)
+	}
+}
+
+func TestWithRecoverOpenerPanic(t *testing.T) {
+	var shouldPanic atomic.Bool
+	shouldPanic.Store(true)
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if shouldPanic.Load() {
+			var cfgPtr *testConfig
+			_ = cfgPtr.Name // nil 指针解引用
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser(), WithRecoverOpenerPanic[testConfig, *testResource]())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	_, err := g.Get(ctx, "res1")
+	if !errors.Is(err, ErrOpenerPanicked) {
+		t.Fatalf("expected ErrOpenerPanicked, got %v", err)
+	}
+	if !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected panic to surface as ErrOpenResourceFailed from Get, got %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "nil pointer dereference") || !strings.Contains(msg, "goroutine") {
+		t.Errorf("expected error to include recovered value and stack, got %q", msg)
+	}
+
+	// 锁已释放，管理器可以继续使用
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Register(ctx, "res2", testConfig{Name: "res2"})
+		m.ListGroupNames()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("manager lock was not released after opener panic")
+	}
+
+	shouldPanic.Store(false)
+	if _, err := g.Get(ctx, "res1"); err != nil {
+		t.Errorf("Get should succeed once opener stops panicking, got %v", err)
+	}
+}
+
+func TestOpenerPanic_PropagatesByDefault(t *testing.T) {
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		panic("boom")
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("expected panic to propagate without the option, got %v", r)
+			}
+		}()
+		g.Get(ctx, "res1")
+	}()
+}
+
+func TestGroup_Ping_RecoversOpenerPanic(t *testing.T) {
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Name == "panic" {
+			panic("ping boom")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser(), WithRecoverOpenerPanic[testConfig, *testResource]())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "ok", testConfig{Name: "ok"})
+	g.Register(ctx, "panic", testConfig{Name: "panic"})
+
+	if err := g.Ping(ctx, "ok"); err != nil {
+		t.Fatalf("Ping ok failed: %v", err)
+	}
+	if err := g.Ping(ctx, "panic"); !errors.Is(err, ErrPingResourceFailed) {
+		t.Errorf("expected ErrPingResourceFailed, got %v", err)
+	}
+
+	// Ping 的探测不计入共享实例的统计
+	if n := g.OpenCount("panic"); n != 0 {
+		t.Errorf("expected OpenCount 0 after Ping, got %d", n)
+	}
+	if _, _, ok := g.LastError("panic"); ok {
+		t.Error("Ping failures should not be recorded by LastError")
+	}
+}
+
+func TestGroup_Configs(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	want := map[string]testConfig{
+		"res1": {Name: "res1", Value: 1},
+		"res2": {Name: "res2", Value: 2},
+		"res3": {Name: "res3", Value: 3},
+	}
+	for name, cfg := range want {
+		g.Register(ctx, name, cfg)
+	}
+
+	got := g.Configs()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d configs, got %d", len(want), len(got))
+	}
+	for name, cfg := range want {
+		if got[name] != cfg {
+			t.Errorf("config %q: expected %+v, got %+v", name, cfg, got[name])
+		}
+	}
+
+	// 修改返回值不影响注册表
+	got["res1"] = testConfig{Name: "changed"}
+	delete(got, "res2")
+	if cfg, _ := g.Config(ctx, "res1"); cfg != want["res1"] {
+		t.Errorf("registry config aliased by Configs result: %+v", cfg)
+	}
+	if again := g.Configs(); len(again) != 3 {
+		t.Errorf("expected registry to be unaffected, got %v", again)
+	}
+
+	m.Close(ctx)
+	if c := g.Configs(); c == nil || len(c) != 0 {
+		t.Errorf("expected non-nil empty map for missing group, got %v", c)
+	}
+}
+
+func TestGroup_GetFresh(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+
+	old, _ := g.Get(ctx, "res1")
+	fresh, err := g.GetFresh(ctx, "res1")
+	if err != nil {
+		t.Fatalf("GetFresh failed: %v", err)
+	}
+	if fresh == old {
+		t.Error("GetFresh should return a new instance")
+	}
+	if !old.Closed {
+		t.Error("GetFresh should close the previous instance")
+	}
+	if fresh.Closed || fresh.Config.Value != 1 {
+		t.Errorf("unexpected fresh instance: %+v", fresh)
+	}
+	if cached, _ := g.Get(ctx, "res1"); cached != fresh {
+		t.Error("GetFresh should cache the new instance")
+	}
+	if n := g.OpenCount("res1"); n != 2 {
+		t.Errorf("expected opener to run twice, got %d", n)
+	}
+
+	// 未初始化的资源直接创建
+	g.Register(ctx, "res2", testConfig{Name: "res2"})
+	if res, err := g.GetFresh(ctx, "res2"); err != nil || res == nil {
+		t.Errorf("GetFresh on pending resource failed: %v", err)
+	}
+	if _, err := g.GetFresh(ctx, "missing"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+}
+
+func TestGroup_GetFresh_Errors(t *testing.T) {
+	var fail atomic.Bool
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if fail.Load() {
+			return nil, errors.New("open failed")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newFailingCloser("close failed"))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Get(ctx, "res1")
+
+	// closer 错误不影响重建
+	if _, err := g.GetFresh(ctx, "res1"); err != nil {
+		t.Errorf("closer error should be ignored, got %v", err)
+	}
+
+	fail.Store(true)
+	if _, err := g.GetFresh(ctx, "res1"); !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected ErrOpenResourceFailed, got %v", err)
+	}
+	if v, _ := g.View("res1"); v.Ready {
+		t.Error("resource should be left uninitialized after a failed rebuild")
+	}
+}
+
+func TestManager_ListGroupNamesPage(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	for i := 9; i >= 0; i-- {
+		m.AddGroup(fmt.Sprintf("group%02d", i))
+	}
+
+	tests := []struct {
+		name          string
+		offset, limit int
+		want          string
+	}{
+		{"first page", 0, 3, "group00,group01,group02"},
+		{"middle page", 3, 3, "group03,group04,group05"},
+		{"last partial page", 9, 3, "group09"},
+		{"out of range offset", 10, 3, ""},
+		{"negative offset", -5, 2, "group00,group01"},
+		{"zero limit", 0, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, total := m.ListGroupNamesPage(tt.offset, tt.limit)
+			if total != 10 {
+				t.Errorf("expected total 10, got %d", total)
+			}
+			if names == nil {
+				t.Error("expected non-nil slice")
+			}
+			if got := strings.Join(names, ","); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestManager_ListGroupNamesPage_Invalidation(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("b")
+	m.AddGroup("c")
+
+	if names, total := m.ListGroupNamesPage(0, 10); total != 2 || strings.Join(names, ",") != "b,c" {
+		t.Fatalf("expected [b c]/2, got %v/%d", names, total)
+	}
+
+	m.AddGroup("a")
+	if names, total := m.ListGroupNamesPage(0, 10); total != 3 || strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("expected cache to be rebuilt after AddGroup, got %v/%d", names, total)
+	}
+
+	g, _ := m.Group("b")
+	g.Close(ctx)
+	if names, total := m.ListGroupNamesPage(0, 10); total != 2 || strings.Join(names, ",") != "a,c" {
+		t.Errorf("expected cache to be rebuilt after Group.Close, got %v/%d", names, total)
+	}
+
+	// 修改返回的切片不应影响缓存
+	names, _ := m.ListGroupNamesPage(0, 1)
+	names[0] = "zzz"
+	if again, _ := m.ListGroupNamesPage(0, 1); again[0] != "a" {
+		t.Errorf("expected cached names to be unaffected by caller mutation, got %v", again)
+	}
+
+	m.Close(ctx)
+	if names, total := m.ListGroupNamesPage(0, 10); total != 0 || len(names) != 0 {
+		t.Errorf("expected no groups after Close, got %v/%d", names, total)
+	}
+}
+
+func TestGroup_CanRegister(t *testing.T) {
+	validator := WithConfigValidator[testConfig, *testResource](func(cfg testConfig) error {
+		if cfg.Value < 0 {
+			return errors.New("negative value")
+		}
+		return nil
+	})
+	m := newManager(newTestOpener(), newTestCloser(), validator)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "taken", testConfig{Name: "taken"})
+
+	if ok, reason := g.CanRegister("free", testConfig{Name: "free"}); !ok || reason != nil {
+		t.Errorf("expected free name to be registrable, got (%v, %v)", ok, reason)
+	}
+	if ok, reason := g.CanRegister("taken", testConfig{Name: "taken"}); ok || !errors.Is(reason, ErrResourceExists) {
+		t.Errorf("expected ErrResourceExists for taken name, got (%v, %v)", ok, reason)
+	}
+	ok, reason := g.CanRegister("bad", testConfig{Name: "bad", Value: -1})
+	if ok || !errors.Is(reason, ErrInvalidConfig) || !strings.Contains(reason.Error(), "negative value") {
+		t.Errorf("expected validator error, got (%v, %v)", ok, reason)
+	}
+
+	// 不修改任何状态
+	names := g.List()
+	if len(names) != 1 || names[0] != "taken" {
+		t.Errorf("CanRegister should not register anything, got %v", names)
+	}
+
+	m.Freeze()
+	if ok, reason := g.CanRegister("free", testConfig{Name: "free"}); ok || !errors.Is(reason, ErrFrozen) {
+		t.Errorf("expected ErrFrozen while frozen, got (%v, %v)", ok, reason)
+	}
+}
+
+func TestCollectReady(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+	g.Register(ctx, "res2", testConfig{Name: "res2", Value: 2})
+	g.Register(ctx, "pending", testConfig{Name: "pending", Value: 3})
+	g.Get(ctx, "res1")
+	g.Get(ctx, "res2")
+
+	got := CollectReady(ctx, g, func(name string, r *testResource) string {
+		return fmt.Sprintf("%s=%d", r.Config.Name, r.Config.Value)
+	})
+	if len(got) != 2 || got["res1"] != "res1=1" || got["res2"] != "res2=2" {
+		t.Errorf("unexpected result: %v", got)
+	}
+	if _, ok := got["pending"]; ok {
+		t.Error("pending resources should be excluded")
+	}
+
+	m.Close(ctx)
+	if got := CollectReady(ctx, g, func(string, *testResource) int { return 0 }); got == nil || len(got) != 0 {
+		t.Errorf("expected empty map for missing group, got %v", got)
+	}
+}
+
+// concurrentGroup 忽略调用方传入的并发数，用于验证 CollectReady 不依赖串行回调。
+type concurrentGroup[C any, T any] struct {
+	Group[C, T]
+}
+
+func (g concurrentGroup[C, T]) ForEachConcurrent(ctx context.Context, _ int, fn func(ctx context.Context, name string, val T) error) error {
+	return g.Group.ForEachConcurrent(ctx, 16, fn)
+}
+
+func TestCollectReady_ConcurrentGroup(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("res%02d", i)
+		g.Register(ctx, name, testConfig{Name: name, Value: i})
+		g.Get(ctx, name)
+	}
+
+	got := CollectReady(ctx, Group[testConfig, *testResource](concurrentGroup[testConfig, *testResource]{g}), func(name string, r *testResource) int {
+		return r.Config.Value
+	})
+	if len(got) != 50 {
+		t.Fatalf("expected 50 results, got %d", len(got))
+	}
+	for i := 0; i < 50; i++ {
+		if v := got[fmt.Sprintf("res%02d", i)]; v != i {
+			t.Errorf("expected res%02d=%d, got %d", i, i, v)
+		}
+	}
+}
+
+func TestManager_FindResource(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("db")
+	m.AddGroup("cache")
+	db, _ := m.Group("db")
+	cache, _ := m.Group("cache")
+	db.Register(ctx, "orders", testConfig{Name: "orders", Value: 1})
+	db.Register(ctx, "primary", testConfig{Name: "primary"})
+	cache.Register(ctx, "primary", testConfig{Name: "primary"})
+
+	groupName, res, err := m.FindResource(ctx, "orders")
+	if err != nil {
+		t.Fatalf("FindResource failed: %v", err)
+	}
+	if groupName != "db" || res.Config.Value != 1 {
+		t.Errorf("unexpected result: group=%q res=%+v", groupName, res.Config)
+	}
+	if cached, _ := db.Get(ctx, "orders"); cached != res {
+		t.Error("FindResource should initialize the shared instance")
+	}
+
+	if _, _, err := m.FindResource(ctx, "missing"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+
+	_, _, err = m.FindResource(ctx, "primary")
+	if !errors.Is(err, ErrAmbiguousResource) {
+		t.Fatalf("expected ErrAmbiguousResource, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `["cache" "db"]`) {
+		t.Errorf("expected error to list the groups, got %v", err)
+	}
+	if s := m.ManagerStats(); s.Ready != 1 {
+		t.Errorf("ambiguous lookup should not initialize anything, got %+v", s)
+	}
+}
+
+func TestManager_RecentEvents(t *testing.T) {
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Name == "bad" {
+			return nil, errors.New("open failed")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser(), WithEventLog[testConfig, *testResource](16))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "bad", testConfig{Name: "bad"})
+	g.Get(ctx, "res1")
+	g.Get(ctx, "bad")
+	g.Unregister(ctx, "res1")
+
+	type step struct {
+		kind EventKind
+		name string
+	}
+	want := []step{
+		{EventRegister, "res1"},
+		{EventRegister, "bad"},
+		{EventOpen, "res1"},
+		{EventOpenFailed, "bad"},
+		{EventClose, "res1"},
+		{EventUnregister, "res1"},
+	}
+	events := m.RecentEvents()
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i, e := range events {
+		if e.Kind != want[i].kind || e.Name != want[i].name || e.Group != "group1" {
+			t.Errorf("event %d: expected %s group1/%s, got %s %s/%s", i, want[i].kind, want[i].name, e.Kind, e.Group, e.Name)
+		}
+		if e.Time.IsZero() {
+			t.Errorf("event %d: expected non-zero time", i)
+		}
+		if i > 0 && e.Time.Before(events[i-1].Time) {
+			t.Errorf("event %d: expected events in chronological order", i)
+		}
+	}
+	if events[3].Err == nil || events[3].Err.Error() != "open failed" {
+		t.Errorf("expected open failure error, got %v", events[3].Err)
+	}
+
+	// 返回的是副本
+	events[0].Name = "changed"
+	if m.RecentEvents()[0].Name != "res1" {
+		t.Error("RecentEvents should return a copy")
+	}
+}
+
+func TestManager_RecentEvents_Capacity(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser(), WithEventLog[testConfig, *testResource](3))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("res%d", i)
+		g.Register(ctx, name, testConfig{Name: name})
+	}
+
+	events := m.RecentEvents()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	for i, e := range events {
+		if want := fmt.Sprintf("res%d", i+2); e.Name != want {
+			t.Errorf("event %d: expected %s, got %s", i, want, e.Name)
+		}
+	}
+}
+
+func TestManager_RecentEvents_Disabled(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	if events := m.RecentEvents(); events == nil || len(events) != 0 {
+		t.Errorf("expected empty non-nil events, got %v", events)
+	}
+}
+
+func TestEqualConfigs(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("a")
+	m.AddGroup("b")
+	a, _ := m.Group("a")
+	b, _ := m.Group("b")
+
+	a.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+	a.Register(ctx, "res2", testConfig{Name: "res2", Value: 2})
+	b.Register(ctx, "res2", testConfig{Name: "res2", Value: 2})
+	b.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+
+	// 初始化状态不影响比较
+	a.Get(ctx, "res1")
+	if !EqualConfigs(a, b) {
+		t.Error("groups with same registrations should be equal")
+	}
+
+	// 资源名不同
+	b.Register(ctx, "res3", testConfig{Name: "res3"})
+	if EqualConfigs(a, b) {
+		t.Error("groups with different names should not be equal")
+	}
+	b.Unregister(ctx, "res3")
+
+	// 配置不同
+	b.Unregister(ctx, "res2")
+	b.Register(ctx, "res2", testConfig{Name: "res2", Value: 20})
+	if EqualConfigs(a, b) {
+		t.Error("groups with different configs should not be equal")
+	}
+}
+
+func TestEqualConfigsFunc(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("a")
+	m.AddGroup("b")
+	a, _ := m.Group("a")
+	b, _ := m.Group("b")
+
+	a.Register(ctx, "res1", testConfig{Name: "res1", Value: 1})
+	b.Register(ctx, "res1", testConfig{Name: "res1", Value: 2})
+
+	byName := func(x, y testConfig) bool { return x.Name == y.Name }
+	if !EqualConfigsFunc(a, b, byName) {
+		t.Error("configs equal by name should be equal")
+	}
+	if EqualConfigsFunc(a, b, func(x, y testConfig) bool { return x == y }) {
+		t.Error("configs with different values should not be equal")
+	}
+
+	b.Register(ctx, "res2", testConfig{Name: "res2"})
+	if EqualConfigsFunc(a, b, byName) {
+		t.Error("groups with different names should not be equal")
+	}
+}
+
+func TestGroup_GetScoped(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	scoped := WithScope(ctx)
+	first, err := g.GetScoped(scoped, "res1")
+	if err != nil {
+		t.Fatalf("GetScoped failed: %v", err)
+	}
+
+	// 作用域内重新创建共享实例后，仍返回第一次获取的实例
+	fresh, err := g.GetFresh(ctx, "res1")
+	if err != nil {
+		t.Fatalf("GetFresh failed: %v", err)
+	}
+	if fresh == first {
+		t.Fatal("GetFresh should create a new instance")
+	}
+	second, err := g.GetScoped(scoped, "res1")
+	if err != nil {
+		t.Fatalf("GetScoped failed: %v", err)
+	}
+	if second != first {
+		t.Error("GetScoped should return the first instance within the same scope")
+	}
+
+	// 新的作用域获取当前共享实例
+	other, _ := g.GetScoped(WithScope(ctx), "res1")
+	if other != fresh {
+		t.Error("new scope should return the current shared instance")
+	}
+
+	// 不携带作用域时等价于 Get
+	plain, _ := g.GetScoped(ctx, "res1")
+	if plain != fresh {
+		t.Error("GetScoped without scope should behave like Get")
+	}
+}
+
+func TestGroup_GetScoped_Error(t *testing.T) {
+	m := newManager(newFailingOpener("open failed"), newTestCloser())
+	ctx := WithScope(context.Background())
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	if _, err := g.GetScoped(ctx, "missing"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	if _, err := g.GetScoped(ctx, "res1"); !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected ErrOpenResourceFailed, got %v", err)
+	}
+}
+
+func TestGroup_GetScoped_NilInterface(t *testing.T) {
+	var opens atomic.Int32
+	opener := func(ctx context.Context, cfg testConfig) (io.Closer, error) {
+		opens.Add(1)
+		return nil, nil
+	}
+	m := newManager[testConfig, io.Closer](opener, nil)
+	ctx := WithScope(context.Background())
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	for i := 0; i < 2; i++ {
+		val, err := g.GetScoped(ctx, "res1")
+		if err != nil || val != nil {
+			t.Errorf("call %d: expected (nil, nil), got (%v, %v)", i, val, err)
+		}
+	}
+	if n := opens.Load(); n != 1 {
+		t.Errorf("expected 1 open, got %d", n)
+	}
+}
+
+func TestWithRecoverCloserPanic(t *testing.T) {
+	closer := func(ctx context.Context, r *testResource) error {
+		if r.Config.Name == "bad" {
+			panic("closer boom")
+		}
+		r.Closed = true
+		return nil
+	}
+	m := newManager(newTestOpener(), closer, WithRecoverCloserPanic[testConfig, *testResource]())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "bad", testConfig{Name: "bad"})
+	g.Register(ctx, "good", testConfig{Name: "good"})
+	g.Get(ctx, "bad")
+	good, _ := g.Get(ctx, "good")
+
+	errs := g.Close(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if !errors.Is(errs[0], ErrCloseResourceFailed) || !errors.Is(errs[0], ErrCloserPanicked) {
+		t.Errorf("expected ErrCloseResourceFailed wrapping ErrCloserPanicked, got %v", errs[0])
+	}
+	if !strings.Contains(errs[0].Error(), "closer boom") {
+		t.Errorf("expected panic value in error, got %v", errs[0])
+	}
+	if !good.Closed {
+		t.Error("remaining resources should still be closed")
+	}
+	if _, err := m.Group("group1"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("group should be removed after Close, got %v", err)
+	}
+
+	// 锁已释放，管理器可以继续使用
+	m.AddGroup("group2")
+	if names := m.ListGroupNames(); len(names) != 1 || names[0] != "group2" {
+		t.Errorf("expected manager to remain usable, got %v", names)
+	}
+}
+
+func TestGroup_Select(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "host-a", Value: 1})
+	g.Register(ctx, "res2", testConfig{Name: "host-b", Value: 2})
+	g.Register(ctx, "res3", testConfig{Name: "host-a", Value: 3})
+
+	names := g.Select(func(_ string, cfg testConfig) bool { return cfg.Name == "host-a" })
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "res1" || names[1] != "res3" {
+		t.Errorf("expected [res1 res3], got %v", names)
+	}
+
+	if names := g.Select(func(string, testConfig) bool { return false }); len(names) != 0 {
+		t.Errorf("expected empty result, got %v", names)
+	}
+
+	g.Close(ctx)
+	if names := g.Select(func(string, testConfig) bool { return true }); len(names) != 0 {
+		t.Errorf("expected empty result for closed group, got %v", names)
+	}
+}
+
+func TestManager_ClosePerGroup(t *testing.T) {
+	closer := func(ctx context.Context, r *testResource) error {
+		r.Closed = true
+		if r.Config.Name == "bad" {
+			return errors.New("close failed")
+		}
+		return nil
+	}
+	m := newManager(newTestOpener(), closer)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	m.AddGroup("group2")
+	g1, _ := m.Group("group1")
+	g2, _ := m.Group("group2")
+	g1.Register(ctx, "bad", testConfig{Name: "bad"})
+	g1.Register(ctx, "ok1", testConfig{Name: "ok1"})
+	g2.Register(ctx, "ok2", testConfig{Name: "ok2"})
+	bad, _ := g1.Get(ctx, "bad")
+	ok1, _ := g1.Get(ctx, "ok1")
+	ok2, _ := g2.Get(ctx, "ok2")
+
+	results := m.ClosePerGroup(ctx)
+	if len(results) != 1 {
+		t.Fatalf("expected errors for 1 group, got %v", results)
+	}
+	errs := results["group1"]
+	if len(errs) != 1 || !errors.Is(errs[0], ErrCloseResourceFailed) || !strings.Contains(errs[0].Error(), `"bad"`) {
+		t.Errorf("expected close error for group1/bad, got %v", errs)
+	}
+	if !bad.Closed || !ok1.Closed || !ok2.Closed {
+		t.Error("all resources should be attempted")
+	}
+	if names := m.ListGroupNames(); len(names) != 0 {
+		t.Errorf("expected all groups removed, got %v", names)
+	}
+}
+
+func TestManager_ClosePerGroup_Interrupted(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	m.AddGroup("group2")
+	g1, _ := m.Group("group1")
+	g2, _ := m.Group("group2")
+	g1.Register(ctx, "res1", testConfig{Name: "res1"})
+	g2.Register(ctx, "res2", testConfig{Name: "res2"})
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	results := m.ClosePerGroup(cancelled)
+	for _, name := range []string{"group1", "group2"} {
+		if errs := results[name]; len(errs) != 1 || !errors.Is(errs[0], ErrCloseInterrupted) {
+			t.Errorf("%s: expected ErrCloseInterrupted, got %v", name, errs)
+		}
+	}
+	if names := m.ListGroupNames(); len(names) != 2 {
+		t.Errorf("interrupted groups should be kept, got %v", names)
+	}
+}
+
+func TestManager_ClosePerGroup_ClearsAfterAllAttempts(t *testing.T) {
+	var m *manager[testConfig, *testResource]
+	// 关闭任一资源时，所有组的资源都应仍在注册表中
+	var registeredDuringClose []int
+	closer := func(ctx context.Context, r *testResource) error {
+		n := 0
+		for _, groupMap := range m.groups {
+			n += len(groupMap)
+		}
+		registeredDuringClose = append(registeredDuringClose, n)
+		if r.Config.Name == "bad" {
+			return errors.New("close failed")
+		}
+		return nil
+	}
+	m = newManager(newTestOpener(), closer)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	m.AddGroup("group2")
+	g1, _ := m.Group("group1")
+	g2, _ := m.Group("group2")
+	g1.Register(ctx, "bad", testConfig{Name: "bad"})
+	g1.Register(ctx, "ok1", testConfig{Name: "ok1"})
+	g2.Register(ctx, "ok2", testConfig{Name: "ok2"})
+	for _, g := range []Group[testConfig, *testResource]{g1, g2} {
+		for _, name := range g.List() {
+			g.Get(ctx, name)
+		}
+	}
+
+	results := m.ClosePerGroup(ctx)
+	if len(results["group1"]) != 1 || len(results) != 1 {
+		t.Errorf("expected one error in group1, got %v", results)
+	}
+	if !slices.Equal(registeredDuringClose, []int{3, 3, 3}) {
+		t.Errorf("registry should be cleared only after all closes, got %v", registeredDuringClose)
+	}
+	if names := m.ListGroupNames(); len(names) != 0 {
+		t.Errorf("expected all groups removed, got %v", names)
+	}
+}
+
+func TestGroup_WithPrefix(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	tenantA := g.WithPrefix("tenantA:")
+	tenantB := g.WithPrefix("tenantB:")
+
+	if _, err := tenantA.Register(ctx, "db", testConfig{Name: "a-db"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	tenantB.Register(ctx, "db", testConfig{Name: "b-db"})
+	g.Register(ctx, "shared", testConfig{Name: "shared"})
+
+	// 视图中注册的资源在原组中以完整名称可见
+	if cfg, err := g.Config(ctx, "tenantA:db"); err != nil || cfg.Name != "a-db" {
+		t.Errorf("expected tenantA:db in underlying group, got %v, %v", cfg, err)
+	}
+	names := g.List()
+	sort.Strings(names)
+	if !slices.Equal(names, []string{"shared", "tenantA:db", "tenantB:db"}) {
+		t.Errorf("unexpected underlying names: %v", names)
+	}
+
+	// List 只返回作用域内的资源并去掉前缀
+	if names := tenantA.List(); !slices.Equal(names, []string{"db"}) {
+		t.Errorf("expected [db], got %v", names)
+	}
+
+	a, err := tenantA.Get(ctx, "db")
+	if err != nil || a.Config.Name != "a-db" {
+		t.Fatalf("expected tenantA db, got %v, %v", a, err)
+	}
+	if full, _ := g.Get(ctx, "tenantA:db"); full != a {
+		t.Error("scoped Get should return the underlying instance")
+	}
+	if _, err := tenantA.Get(ctx, "shared"); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound for unscoped name, got %v", err)
+	}
+	if v, err := tenantA.View("db"); err != nil || v.Name != "db" || !v.Ready {
+		t.Errorf("unexpected view: %+v, %v", v, err)
+	}
+
+	// 注销只影响作用域内的资源
+	if err := tenantA.Unregister(ctx, "db"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if !a.Closed {
+		t.Error("unregistered resource should be closed")
+	}
+	if names := tenantA.List(); len(names) != 0 {
+		t.Errorf("expected empty scoped list, got %v", names)
+	}
+	if names := tenantB.List(); !slices.Equal(names, []string{"db"}) {
+		t.Errorf("other scope should be unaffected, got %v", names)
+	}
+}
+
+func TestGroup_WithPrefix_GetWithDeps(t *testing.T) {
+	var gotDeps map[string]*testResource
+	depOpener := func(ctx context.Context, cfg testConfig, deps map[string]*testResource) (*testResource, error) {
+		gotDeps = deps
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(newTestOpener(), newTestCloser(), WithDependentOpener(depOpener))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	tenant := g.WithPrefix("tenantA:")
+	tenant.Register(ctx, "old", testConfig{Name: "old"})
+	tenant.Register(ctx, "new", testConfig{Name: "new"})
+	tenant.Register(ctx, "tee", testConfig{Name: "tee"})
+
+	if _, err := tenant.GetWithDeps(ctx, "tee", "old", "new"); err != nil {
+		t.Fatalf("GetWithDeps failed: %v", err)
+	}
+	// 依赖 map 的 key 与调用方传入的资源名一致，不带前缀
+	oldRes, _ := tenant.Get(ctx, "old")
+	newRes, _ := tenant.Get(ctx, "new")
+	if len(gotDeps) != 2 || gotDeps["old"] != oldRes || gotDeps["new"] != newRes {
+		t.Errorf("expected deps keyed by unprefixed names, got %v", gotDeps)
+	}
+}
+
+func TestGroup_WithPrefix_Close(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	tenant := g.WithPrefix("t:")
+	tenant.Register(ctx, "db1", testConfig{Name: "db1"})
+	tenant.Register(ctx, "db2", testConfig{Name: "db2"})
+	g.Register(ctx, "other", testConfig{Name: "other"})
+
+	if cfgs := tenant.Configs(); len(cfgs) != 2 || cfgs["db1"].Name != "db1" {
+		t.Errorf("unexpected scoped configs: %v", cfgs)
+	}
+	if names := tenant.Select(func(name string, _ testConfig) bool { return name == "db2" }); !slices.Equal(names, []string{"db2"}) {
+		t.Errorf("expected [db2], got %v", names)
+	}
+
+	if errs := tenant.Close(ctx); len(errs) != 0 {
+		t.Errorf("unexpected close errors: %v", errs)
+	}
+	if names := g.List(); !slices.Equal(names, []string{"other"}) {
+		t.Errorf("expected only unscoped resource to remain, got %v", names)
+	}
+	if _, err := m.Group("group1"); err != nil {
+		t.Errorf("closing a scoped view should keep the group, got %v", err)
+	}
+
+	// 叠加前缀
+	nested := tenant.WithPrefix("x:")
+	nested.Register(ctx, "db", testConfig{Name: "nested"})
+	if _, err := g.Config(ctx, "t:x:db"); err != nil {
+		t.Errorf("expected nested prefix t:x:db, got %v", err)
+	}
+	if names := tenant.List(); !slices.Equal(names, []string{"x:db"}) {
+		t.Errorf("expected [x:db], got %v", names)
+	}
+}
+
+func TestGroup_AwaitAll(t *testing.T) {
+	var opens atomic.Int32
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		opens.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("res%d", i)
+		g.Register(ctx, name, testConfig{Name: name})
+	}
+
+	// AwaitAll 本身不会触发创建
+	short, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+	defer cancel()
+	if err := g.AwaitAll(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if n := opens.Load(); n != 0 {
+		t.Fatalf("AwaitAll should not open resources, got %d opens", n)
+	}
+
+	go m.WarmupAll(ctx, 2)
+
+	waitCtx, cancelWait := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelWait()
+	if err := g.AwaitAll(waitCtx); err != nil {
+		t.Fatalf("AwaitAll failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if v, _ := g.View(fmt.Sprintf("res%d", i)); !v.Ready {
+			t.Errorf("res%d should be ready after AwaitAll", i)
+		}
+	}
+}
+
+func TestGroup_AwaitAll_Failure(t *testing.T) {
+	m := newManager(newFailingOpener("open failed"), newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		g.Get(ctx, "res1")
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := g.AwaitAll(waitCtx); !errors.Is(err, ErrOpenResourceFailed) {
+		t.Errorf("expected ErrOpenResourceFailed, got %v", err)
+	}
+
+	if err := g.WithPrefix("none:").AwaitAll(ctx); err != nil {
+		t.Errorf("empty scope should return nil, got %v", err)
+	}
+	m.AddGroup("empty")
+	empty, _ := m.Group("empty")
+	empty.Close(ctx)
+	if err := empty.AwaitAll(ctx); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func TestGroup_AwaitAll_UnregisterAndPing(t *testing.T) {
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Name == "down" {
+			return nil, errors.New("unreachable")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser())
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "down", testConfig{Name: "down"})
+
+	done := make(chan error, 1)
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	go func() { done <- g.AwaitAll(waitCtx) }()
+
+	// Ping 失败不影响等待中的 AwaitAll
+	if err := g.Ping(ctx, "down"); err == nil {
+		t.Fatal("expected Ping to fail")
+	}
+	// 未就绪的资源被注销后不再等待
+	if err := g.Unregister(ctx, "down"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if _, err := g.Get(ctx, "res1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("expected AwaitAll to succeed, got %v", err)
+	}
+}
+
+func TestGeneration(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	ctx := context.Background()
+	if m.Generation() != 0 {
+		t.Fatalf("expected initial generation 0, got %d", m.Generation())
+	}
+	m.AddGroup("group1")
+	m.AddGroup("group2")
+	g1, _ := m.Group("group1")
+	g2, _ := m.Group("group2")
+
+	// 每次变更都会递增
+	last := g1.Generation()
+	lastMgr := m.Generation()
+	mutations := []struct {
+		name string
+		fn   func()
+	}{
+		{"Register", func() { g1.Register(ctx, "res1", testConfig{Name: "res1"}) }},
+		{"GetFresh", func() { g1.GetFresh(ctx, "res1") }},
+		{"Unregister", func() { g1.Unregister(ctx, "res1") }},
+		{"Register again", func() { g1.Register(ctx, "res2", testConfig{Name: "res2"}) }},
+		{"Close", func() { g1.Close(ctx) }},
+	}
+	for _, mu := range mutations {
+		mu.fn()
+		if gen := g1.Generation(); gen <= last {
+			t.Errorf("%s: expected group generation > %d, got %d", mu.name, last, gen)
+		}
+		if gen := m.Generation(); gen <= lastMgr {
+			t.Errorf("%s: expected manager generation > %d, got %d", mu.name, lastMgr, gen)
+		}
+		last, lastMgr = g1.Generation(), m.Generation()
+	}
+
+	// 只读操作不改变代数
+	g2.Register(ctx, "res3", testConfig{Name: "res3"})
+	g2.Get(ctx, "res3")
+	gen, genMgr := g2.Generation(), m.Generation()
+	g2.Get(ctx, "res3")
+	g2.List()
+	g2.Configs()
+	m.ListGroupNames()
+	if g2.Generation() != gen || m.Generation() != genMgr {
+		t.Errorf("read-only calls should not change generation: %d->%d, %d->%d", gen, g2.Generation(), genMgr, m.Generation())
+	}
+
+	// 其他组的变更不影响本组代数
+	if g1.Generation() != last {
+		t.Errorf("group1 generation should not change on group2 mutations, got %d want %d", g1.Generation(), last)
+	}
+
+	// 组重建后代数不回退
+	m.AddGroup("group1")
+	if g1.Generation() < last {
+		t.Errorf("generation should not go backwards after group is recreated, got %d want >= %d", g1.Generation(), last)
+	}
+}
+
+func TestGeneration_OnlyActualChanges(t *testing.T) {
+	m := newManager(newFailingOpener("open failed"), newTestCloser())
+	ctx := context.Background()
+
+	before := m.Generation()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	if m.Generation() == before || g.Generation() == 0 {
+		t.Error("creating a group should bump the generation")
+	}
+	if existed := m.AddGroup("group1"); !existed {
+		t.Fatal("expected group1 to exist")
+	}
+
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	gen, genMgr := g.Generation(), m.Generation()
+
+	// 资源从未初始化：关闭不改变状态，重建失败也不改变状态
+	g.CloseResources(ctx)
+	if _, err := g.GetFresh(ctx, "res1"); !errors.Is(err, ErrOpenResourceFailed) {
+		t.Fatalf("expected ErrOpenResourceFailed, got %v", err)
+	}
+	m.AddGroup("group1")
+	if g.Generation() != gen || m.Generation() != genMgr {
+		t.Errorf("no-op changes should not bump the generation: %d->%d, %d->%d", gen, g.Generation(), genMgr, m.Generation())
+	}
+}
+
+func TestGroup_Generation_UnknownGroup(t *testing.T) {
+	m := newTestManager(newTestOpener(), newTestCloser())
+	g := &group[testConfig, *testResource]{name: "missing", m: m}
+
+	for i := 0; i < 3; i++ {
+		if gen := g.Generation(); gen != 0 {
+			t.Fatalf("expected generation 0 for unknown group, got %d", gen)
+		}
+	}
+	if _, ok := m.groupGens.Load("missing"); ok {
+		t.Error("Generation should not create a counter for an unknown group")
+	}
+
+	g.Register(context.Background(), "res1", testConfig{Name: "res1"})
+	if gen := g.Generation(); gen == 0 {
+		t.Error("expected generation to advance after Register")
+	}
+}
+
+func TestGroup_Replace(t *testing.T) {
+	closed := make(chan *testResource, 1)
+	closer := func(ctx context.Context, r *testResource) error {
+		r.Closed = true
+		closed <- r
+		return nil
+	}
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Value < 0 {
+			return nil, errors.New("bad credentials")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, closer)
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "db", testConfig{Name: "db", Value: 1})
+	old, _ := g.Get(ctx, "db")
+
+	// 创建失败时保留旧实例和旧配置
+	if _, err := g.Replace(ctx, "db", testConfig{Name: "db", Value: -1}); !errors.Is(err, ErrOpenResourceFailed) {
+		t.Fatalf("expected ErrOpenResourceFailed, got %v", err)
+	}
+	if cur, _ := g.Get(ctx, "db"); cur != old || old.Closed {
+		t.Error("failed Replace should keep the old instance open")
+	}
+	if cfg, _ := g.Config(ctx, "db"); cfg.Value != 1 {
+		t.Errorf("failed Replace should keep the old config, got %v", cfg)
+	}
+
+	// 创建成功后替换为新实例，旧实例在后台关闭
+	replaced, err := g.Replace(ctx, "db", testConfig{Name: "db", Value: 2})
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if replaced == old || replaced.Config.Value != 2 {
+		t.Errorf("expected new instance with new config, got %+v", replaced)
+	}
+	if cur, _ := g.Get(ctx, "db"); cur != replaced {
+		t.Error("Get should return the replaced instance")
+	}
+	if cfg, _ := g.Config(ctx, "db"); cfg.Value != 2 {
+		t.Errorf("expected new config, got %v", cfg)
+	}
+	select {
+	case r := <-closed:
+		if r != old {
+			t.Error("expected old instance to be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("old instance was not closed")
+	}
+
+	if _, err := g.Replace(ctx, "missing", testConfig{}); !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("expected ErrResourceNotFound, got %v", err)
+	}
+}
+
+func TestGroup_RegisterWithTTL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	m := newManager(newTestOpener(), newTestCloser(), WithClock[testConfig, *testResource](func() time.Time { return now }))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	if isNew, err := g.RegisterWithTTL(ctx, "token", testConfig{Name: "token"}, time.Minute); !isNew || err != nil {
+		t.Fatalf("RegisterWithTTL failed: %v, %v", isNew, err)
+	}
+	g.Register(ctx, "plain", testConfig{Name: "plain"})
+
+	first, _ := g.Get(ctx, "token")
+	plain, _ := g.Get(ctx, "plain")
+	now = now.Add(59 * time.Second)
+	if again, _ := g.Get(ctx, "token"); again != first {
+		t.Fatal("Get before expiry should return the same instance")
+	}
+
+	now = now.Add(time.Second)
+
+	second, err := g.Get(ctx, "token")
+	if err != nil {
+		t.Fatalf("Get after expiry failed: %v", err)
+	}
+	if second == first {
+		t.Error("Get after expiry should create a new instance")
+	}
+	if !first.Closed {
+		t.Error("expired instance should be closed")
+	}
+	if second.Closed {
+		t.Error("new instance should not be closed")
+	}
+	if n := g.OpenCount("token"); n != 2 {
+		t.Errorf("expected 2 opens, got %d", n)
+	}
+
+	// 新实例的存活时间从重新创建时开始计算
+	now = now.Add(30 * time.Second)
+	if again, _ := g.Get(ctx, "token"); again != second {
+		t.Error("recreated instance should not expire before its own ttl")
+	}
+
+	// 未设置 TTL 的资源不过期
+	now = now.Add(24 * time.Hour)
+	if again, _ := g.Get(ctx, "plain"); again != plain || plain.Closed {
+		t.Error("resource without TTL should not expire")
+	}
+}
+
+func TestWithClock_Timestamps(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	opener := func(ctx context.Context, cfg testConfig) (*testResource, error) {
+		if cfg.Name == "down" {
+			return nil, errors.New("unreachable")
+		}
+		return &testResource{Config: cfg}, nil
+	}
+	m := newManager(opener, newTestCloser(),
+		WithClock[testConfig, *testResource](func() time.Time { return now }),
+		WithEventLog[testConfig, *testResource](8))
+	ctx := context.Background()
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+	g.Register(ctx, "res1", testConfig{Name: "res1"})
+	g.Register(ctx, "down", testConfig{Name: "down"})
+
+	g.Get(ctx, "res1")
+	if at, ok := g.LastAccess("res1"); !ok || !at.Equal(now) {
+		t.Errorf("expected LastAccess %v, got %v (%v)", now, at, ok)
+	}
+	now = now.Add(time.Minute)
+	g.Touch("res1")
+	if at, _ := g.LastAccess("res1"); !at.Equal(now) {
+		t.Errorf("expected LastAccess after Touch %v, got %v", now, at)
+	}
+
+	now = now.Add(time.Minute)
+	g.Get(ctx, "down")
+	if _, at, ok := g.LastError("down"); !ok || !at.Equal(now) {
+		t.Errorf("expected LastError at %v, got %v (%v)", now, at, ok)
+	}
+
+	for _, e := range m.RecentEvents() {
+		if e.Time.Before(time.Unix(1700000000, 0)) || e.Time.After(now) {
+			t.Errorf("event %v not recorded with the injected clock: %v", e.Kind, e.Time)
+		}
+	}
+}
+
+func TestGroup_Register_CancelledContext(t *testing.T) {
+	m := newManager(newTestOpener(), newTestCloser())
+	m.AddGroup("group1")
+	g, _ := m.Group("group1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if isNew, err := g.Register(ctx, "res1", testConfig{Name: "res1"}); isNew || !errors.Is(err, context.Canceled) {
+		t.Errorf("Register: expected (false, context.Canceled), got (%v, %v)", isNew, err)
+	}
+	if _, err := g.RegisterTagged(ctx, "res2", testConfig{Name: "res2"}, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("RegisterTagged: expected context.Canceled, got %v", err)
+	}
+	if _, err := g.RegisterWithTTL(ctx, "res3", testConfig{Name: "res3"}, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("RegisterWithTTL: expected context.Canceled, got %v", err)
+	}
+	if names := g.List(); len(names) != 0 {
+		t.Errorf("cancelled registrations should not add entries, got %v", names)
+	}
+
+	expired, cancelTimeout := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelTimeout()
+	if _, err := g.Register(expired, "res1", testConfig{Name: "res1"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
 // ============== 基准测试 ==============
 
 func BenchmarkGroup_Get_Cached(b *testing.B) {
@@ -0,0 +1,97 @@
+package registry
+
+import (
+	"context"
+	"time"
+)
+
+// Replace 使用新配置创建资源实例，成功后原子地替换资源的配置和共享实例，适用于零停机的凭据轮换。
+//
+// 新实例在锁外通过 Opener 创建，创建期间 Get 继续返回旧实例；创建成功后在写锁内完成替换，
+// 旧实例（如果已初始化）在后台 goroutine 中通过 Closer 关闭，其错误会被忽略。
+// 因此 Get 不会观察到资源不可用的中间状态。
+// 若 Opener 失败，资源的配置和旧实例保持不变。资源的标签保持不变；
+// 已通过 Acquire 创建的实例池不会被替换。
+//
+// 可能返回的错误:
+//   - ErrInvalidConfig: 配置未通过 WithConfigValidator 校验
+//   - ErrFrozen: 管理器已冻结
+//   - ErrGroupNotFound、ErrResourceNotFound: 组或资源不存在（包括在创建期间被注销）
+//   - ErrOpenResourceFailed: 新实例创建失败，旧实例保持不变
+//
+// 示例:
+//
+//	cfg.Password = newPassword
+//	if _, err := group.Replace(ctx, "master", cfg); err != nil {
+//	    log.Printf("rotate credentials failed, keep using old connection: %v", err)
+//	}
+func (g *group[C, T]) Replace(ctx context.Context, name string, cfg C) (T, error) {
+	var zero T
+	if err := g.m.validateConfig(g.name, name, cfg); err != nil {
+		return zero, err
+	}
+
+	// 创建新实例前先检查，避免为不存在的资源调用 Opener
+	g.m.mu.RLock()
+	_, err := g.replaceTarget(name)
+	g.m.mu.RUnlock()
+	if err != nil {
+		return zero, err
+	}
+
+	val, err := g.m.open(ctx, g.name, name, cfg)
+	if err != nil {
+		return zero, NewErrOpenResourceFailed(g.name, name, err)
+	}
+
+	g.m.mu.Lock()
+	conn, err := g.replaceTarget(name)
+	if err != nil {
+		g.m.mu.Unlock()
+		// 资源在创建期间被注销或管理器被冻结，丢弃新实例
+		_ = g.m.callCloser(withResource(ctx, g.name, name), g.name, name, val)
+		return zero, err
+	}
+
+	old, wasReady := conn.val, conn.ready
+	conn.cfg = cfg
+	conn.val = val
+	conn.ready = true
+	conn.openedAt = g.m.clock()
+	conn.opens.Add(1)
+	conn.lastErr, conn.lastErrAt = nil, time.Time{}
+	conn.touch(g.m.clock())
+	g.m.bumpGeneration(g.name)
+	if !wasReady {
+		g.m.queueReadyChange(g.name, name, true)
+	}
+	g.m.unlockAndNotify()
+
+	if wasReady {
+		// 旧实例可能仍被其他 goroutine 使用，在后台关闭，不受调用方 ctx 取消的影响
+		closeCtx := withResource(context.WithoutCancel(ctx), g.name, name)
+		go func() {
+			closeErr := g.m.callCloser(closeCtx, g.name, name, old)
+			g.m.recordEvent(EventClose, g.name, name, closeErr)
+		}()
+	}
+	return val, nil
+}
+
+// replaceTarget 返回 Replace 要替换的资源。
+//
+// 调用方必须持有 manager 的锁。
+func (g *group[C, T]) replaceTarget(name string) (*connection[C, T], error) {
+	if g.m.frozen {
+		return nil, NewErrFrozen(g.name)
+	}
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return nil, NewErrGroupNotFound(g.name)
+	}
+	conn, ok := groupMap[name]
+	if !ok {
+		return nil, NewErrResourceNotFound(g.name, name)
+	}
+	return conn, nil
+}
@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+)
+
+// GetByKey 根据路由键在组内选择一个固定的资源并返回（按需惰性初始化）。
+//
+// 适用于按用户 ID 分片等粘性路由场景。选择采用 rendezvous（最高随机权重）一致性哈希：
+// 对每个已注册的资源名计算 hash(routingKey, name)，取权重最大者。资源集合不变时，相同的键始终映射到同一个资源；
+// 增删某个资源只会影响原本映射到该资源的键，其余键保持不变。
+//
+// 返回值:
+//   - name: 选中的资源名
+//   - val: 选中的资源实例
+//   - err: 组不存在时返回 ErrGroupNotFound；组内没有资源时返回 ErrNoResources；
+//     资源初始化失败时返回 Get 的错误
+//
+// 示例:
+//
+//	name, db, err := shards.GetByKey(ctx, strconv.FormatInt(userID, 10))
+func (g *group[C, T]) GetByKey(ctx context.Context, routingKey string) (name string, val T, err error) {
+	return g.getByKey(ctx, routingKey, nil)
+}
+
+// getByKey 实现 GetByKey，只在 match 返回 true 的资源中选择；match 为 nil 时考虑全部资源。
+func (g *group[C, T]) getByKey(ctx context.Context, routingKey string, match func(name string) bool) (name string, val T, err error) {
+	g.m.mu.RLock()
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		g.m.mu.RUnlock()
+		return "", val, NewErrGroupNotFound(g.name)
+	}
+	names := make([]string, 0, len(groupMap))
+	for n := range groupMap {
+		if match == nil || match(n) {
+			names = append(names, n)
+		}
+	}
+	g.m.mu.RUnlock()
+
+	if len(names) == 0 {
+		return "", val, NewErrNoResources(g.name)
+	}
+
+	// 排序保证权重相同时的选择也是确定的
+	sort.Strings(names)
+	var best uint64
+	for i, n := range names {
+		if w := rendezvousWeight(routingKey, n); i == 0 || w > best {
+			name, best = n, w
+		}
+	}
+
+	val, err = g.Get(ctx, name)
+	if err != nil {
+		return "", val, err
+	}
+	return name, val, nil
+}
+
+// rendezvousWeight 计算路由键与资源名组合后的哈希权重。
+func rendezvousWeight(routingKey, name string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(routingKey))
+	h.Write([]byte{0})
+	h.Write([]byte(name))
+	return h.Sum64()
+}
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"context"
+	"sync"
+)
+
+// scopeKey 是存放请求级缓存的 context key 类型。
+type scopeKey struct{}
+
+// scopeEntry 标识请求级缓存中的一个资源，包含所属管理器以区分不同注册表中的同名资源。
+type scopeEntry struct {
+	owner any
+	group string
+	name  string
+}
+
+// scopedValue 包装缓存的资源实例。
+//
+// T 为接口类型时实例可能为 nil，直接以 any 保存后无法通过 v.(T) 取回，因此使用具体类型包装。
+type scopedValue[T any] struct {
+	val T
+}
+
+// scope 是 WithScope 注入到 ctx 中的请求级资源缓存。
+type scope struct {
+	mu   sync.Mutex
+	vals map[scopeEntry]any // vals 的值为 scopedValue[T]
+}
+
+// WithScope 返回携带请求级资源缓存的 ctx，配合 Group.GetScoped 使用。
+//
+// 在同一个作用域内，某个资源第一次通过 GetScoped 获取到的实例会被缓存，
+// 后续的 GetScoped 调用始终返回该实例，即使期间资源被 GetFresh 等操作重新创建。
+// 缓存只保存实例引用，不负责关闭资源。
+//
+// 示例:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    ctx := registry.WithScope(r.Context())
+//	    db, err := group.GetScoped(ctx, "master")
+//	    // 本次请求中后续的 GetScoped(ctx, "master") 都返回同一个 db
+//	}
+func WithScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &scope{vals: make(map[scopeEntry]any)})
+}
+
+// GetScoped 在 WithScope 创建的请求作用域内获取资源，同一作用域内重复调用返回同一个实例。
+//
+// 作用域内首次调用时通过 Get 获取资源并缓存；获取失败时不缓存，下次调用会重试。
+// ctx 不携带作用域时等价于 Get。
+//
+// 示例:
+//
+//	ctx = registry.WithScope(ctx)
+//	db1, _ := group.GetScoped(ctx, "master")
+//	group.GetFresh(context.Background(), "master") // 重新创建共享实例
+//	db2, _ := group.GetScoped(ctx, "master")       // db2 == db1
+func (g *group[C, T]) GetScoped(ctx context.Context, name string) (T, error) {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return g.Get(ctx, name)
+	}
+	key := scopeEntry{owner: g.m, group: g.name, name: name}
+
+	s.mu.Lock()
+	v, ok := s.vals[key]
+	s.mu.Unlock()
+	if ok {
+		return v.(scopedValue[T]).val, nil
+	}
+
+	// Get 在作用域锁外执行，避免 opener 内再次调用 GetScoped 时死锁
+	val, err := g.Get(ctx, name)
+	if err != nil {
+		return val, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// 并发调用时以先写入的实例为准
+	if v, ok := s.vals[key]; ok {
+		return v.(scopedValue[T]).val, nil
+	}
+	s.vals[key] = scopedValue[T]{val: val}
+	return val, nil
+}
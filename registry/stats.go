@@ -0,0 +1,126 @@
+package registry
+
+import "time"
+
+// ManagerStats 是整个管理器的统计快照，由 Manager.ManagerStats 返回。
+//
+// 适合作为指标采集的单一数据源，所有计数在同一次读锁内采集。
+type ManagerStats struct {
+	Groups        int    // Groups 是当前资源组数量
+	Registered    int    // Registered 是所有组内已注册的资源总数
+	Ready         int    // Ready 是所有组内已完成初始化的资源总数
+	OpenSuccesses uint64 // OpenSuccesses 是 opener 成功创建资源的累计次数
+	OpenFailures  uint64 // OpenFailures 是 opener 返回错误的累计次数
+}
+
+// ManagerStats 返回整个管理器的统计快照。
+//
+// Groups、Registered 和 Ready 反映调用时刻的状态；OpenSuccesses 和 OpenFailures 是自管理器创建以来的累计值，
+// 统计所有创建路径（Get、Acquire、WarmupAll），Close 或 Unregister 不会将其清零。
+//
+// 示例:
+//
+//	s := mgr.ManagerStats()
+//	fmt.Printf("groups=%d registered=%d ready=%d failures=%d\n", s.Groups, s.Registered, s.Ready, s.OpenFailures)
+func (m *manager[C, T]) ManagerStats() ManagerStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := ManagerStats{
+		Groups:        len(m.groups),
+		OpenSuccesses: m.openSuccesses.Load(),
+		OpenFailures:  m.openFailures.Load(),
+	}
+	for _, groupMap := range m.groups {
+		stats.Registered += len(groupMap)
+		for _, conn := range groupMap {
+			if conn.ready {
+				stats.Ready++
+			}
+		}
+	}
+	return stats
+}
+
+// OpenCount 返回为指定资源的共享实例实际调用 opener 的次数（包括失败的调用）。
+//
+// 统计 Get（含 GetWithDeps 等基于 Get 的方法）和 WarmupAll 的初始化，不包括 Acquire 实例池创建的实例。
+// 并发 Get 同一个未初始化的资源时 opener 只会执行一次，可借此在应用代码中验证该保证。
+// 资源被注销后计数随之丢弃，重新注册后从 0 开始。
+//
+// 返回值:
+//   - uint64: opener 调用次数；组或资源不存在时返回 0
+func (g *group[C, T]) OpenCount(name string) uint64 {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	conn, ok := g.m.groups[g.name][name]
+	if !ok {
+		return 0
+	}
+	return conn.opens.Load()
+}
+
+// LastError 返回指定资源最近一次初始化失败的错误及发生时间，适用于在健康看板上展示资源不可用的原因。
+//
+// 记录 Get（含基于 Get 的方法）和 WarmupAll 中 opener 返回的原始错误；资源成功初始化后记录会被清空。
+//
+// 返回值:
+//   - err: 最近一次 opener 返回的错误
+//   - at: 错误发生的时间
+//   - ok: 是否存在失败记录；组或资源不存在、从未失败或失败后已成功初始化时为 false
+func (g *group[C, T]) LastError(name string) (err error, at time.Time, ok bool) {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	conn, found := g.m.groups[g.name][name]
+	if !found || conn.lastErr == nil {
+		return nil, time.Time{}, false
+	}
+	return conn.lastErr, conn.lastErrAt, true
+}
+
+// Touch 将指定资源的最近访问时间更新为当前时间，但不会获取或初始化资源。
+//
+// Touch 只更新 LastAccess 读取到的时间戳，不会触发或推迟任何回收、关闭操作；
+// 调用方可基于 LastAccess 自行实现空闲检测，例如由外部系统报告资源仍在使用时调用 Touch。
+// Get 成功返回资源时也会更新同一个时间戳。
+// 只持有读锁，时间戳通过原子操作更新，不会阻塞并发的 Get。
+//
+// 返回值:
+//   - error: 组不存在时返回 ErrGroupNotFound，资源不存在时返回 ErrResourceNotFound
+func (g *group[C, T]) Touch(name string) error {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return NewErrGroupNotFound(g.name)
+	}
+	conn, ok := groupMap[name]
+	if !ok {
+		return NewErrResourceNotFound(g.name, name)
+	}
+	conn.touch(g.m.clock())
+	return nil
+}
+
+// LastAccess 返回指定资源最近一次通过 Get 成功获取或 Touch 的时间。
+//
+// 返回值:
+//   - time.Time: 最近访问时间
+//   - bool: 组或资源不存在、或资源从未被访问时为 false
+func (g *group[C, T]) LastAccess(name string) (time.Time, bool) {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	conn, ok := g.m.groups[g.name][name]
+	if !ok {
+		return time.Time{}, false
+	}
+	nanos := conn.lastAccess.Load()
+	if nanos == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
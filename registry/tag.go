@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"context"
+	"maps"
+)
+
+// RegisterTagged 向组中注册一个带标签的资源配置。
+//
+// 除附加标签外，行为与 Register 完全一致；tags 会被复制保存，之后修改传入的 map 不会影响已保存的标签。
+// 如果资源名已存在，不会覆盖原有配置和标签。
+//
+// 示例:
+//
+//	group.RegisterTagged(ctx, "db1", cfg, map[string]string{"region": "us", "role": "replica"})
+//	names := group.FindByTag("region", "us")
+func (g *group[C, T]) RegisterTagged(ctx context.Context, name string, cfg C, tags map[string]string) (bool, error) {
+	return g.register(ctx, name, cfg, maps.Clone(tags), 0)
+}
+
+// FindByTag 返回组内标签 key 的值等于 value 的所有资源名称。
+//
+// 返回的列表顺序不保证固定（依赖 map 遍历顺序）。
+// 如果组不存在或没有匹配的资源，返回空列表。
+func (g *group[C, T]) FindByTag(key, value string) []string {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	var names []string
+	for name, conn := range g.m.groups[g.name] {
+		if v, ok := conn.tags[key]; ok && v == value {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Tags 返回指定资源的标签副本。
+//
+// 未附加标签的资源返回空 map。
+//
+// 可能返回的错误:
+//   - ErrGroupNotFound: 组不存在
+//   - ErrResourceNotFound: 资源未注册
+func (g *group[C, T]) Tags(name string) (map[string]string, error) {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return nil, NewErrGroupNotFound(g.name)
+	}
+	conn, ok := groupMap[name]
+	if !ok {
+		return nil, NewErrResourceNotFound(g.name, name)
+	}
+	tags := make(map[string]string, len(conn.tags))
+	for k, v := range conn.tags {
+		tags[k] = v
+	}
+	return tags, nil
+}
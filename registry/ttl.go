@@ -0,0 +1,50 @@
+package registry
+
+import (
+	"context"
+	"time"
+)
+
+// RegisterWithTTL 向组中注册一个资源配置，并为其共享实例设置固定的最大存活时间。
+//
+// 除附加存活时间外，行为与 Register 完全一致。共享实例创建后超过 ttl 时，
+// 下一次 Get（及基于 Get 的方法）会先通过 Closer 关闭旧实例，再使用当前配置重新创建，
+// 无论期间是否被访问过，适用于有效期固定的令牌等资源。ttl 小于等于 0 时不过期。
+//
+// 过期只在 Get 时检查，不会在后台主动关闭资源；View、ForEachConcurrent 等不触发初始化的方法
+// 可能仍会看到已过期的实例。
+//
+// 示例:
+//
+//	group.RegisterWithTTL(ctx, "token", TokenConfig{Scope: "read"}, time.Hour)
+//	tok, err := group.Get(ctx, "token") // 一小时后再次 Get 会获取新令牌
+func (g *group[C, T]) RegisterWithTTL(ctx context.Context, name string, cfg C, ttl time.Duration) (bool, error) {
+	return g.register(ctx, name, cfg, nil, ttl)
+}
+
+// clock 返回管理器使用的当前时间，用于 TTL 计算以及创建时间、访问时间、错误时间和事件时间等时间戳。
+func (m *manager[C, T]) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// expired 判断共享实例是否已超过通过 RegisterWithTTL 设置的存活时间。
+//
+// 调用方必须持有 manager 的读锁或写锁。
+func (c *connection[C, T]) expired(now time.Time) bool {
+	return c.ready && c.ttl > 0 && now.Sub(c.openedAt) >= c.ttl
+}
+
+// expireConn 关闭已过期的共享实例，并将资源重置为未初始化状态，Closer 的错误会被忽略。
+//
+// 调用方必须持有 manager 的写锁。
+func (m *manager[C, T]) expireConn(ctx context.Context, groupName, name string, conn *connection[C, T]) {
+	closeErr := m.callCloser(withResource(ctx, groupName, name), groupName, name, conn.val)
+	m.recordEvent(EventClose, groupName, name, closeErr)
+	var zero T
+	conn.val = zero
+	conn.ready = false
+	m.queueReadyChange(groupName, name, false)
+}
@@ -0,0 +1,48 @@
+package registry
+
+// ResourceView 是资源在某一时刻的只读快照，由 Group.View 返回。
+//
+// 类型参数:
+//   - C: 配置类型
+//   - T: 资源类型
+type ResourceView[C any, T any] struct {
+	Name   string // Name 是资源名
+	Config C      // Config 是资源配置的副本（与 Config 方法相同，引用类型字段仍与注册表共享）
+	Ready  bool   // Ready 表示资源是否已完成初始化
+	Value  T      // Value 是资源实例，未初始化时为零值
+}
+
+// View 返回指定资源的只读快照，包括名称、配置、就绪状态和资源实例。
+//
+// 所有字段在同一次读锁内采集，避免分别调用 Config、Get 等方法时状态发生变化导致的不一致。
+// View 不会触发惰性初始化；资源未初始化时 Ready 为 false，Value 为零值。
+//
+// 返回值:
+//   - ResourceView[C, T]: 资源快照
+//   - error: 组不存在时返回 ErrGroupNotFound，资源不存在时返回 ErrResourceNotFound
+//
+// 示例:
+//
+//	v, err := group.View("master")
+//	if err == nil && v.Ready {
+//	    useDB(v.Value)
+//	}
+func (g *group[C, T]) View(name string) (ResourceView[C, T], error) {
+	g.m.mu.RLock()
+	defer g.m.mu.RUnlock()
+
+	groupMap, ok := g.m.groups[g.name]
+	if !ok {
+		return ResourceView[C, T]{}, NewErrGroupNotFound(g.name)
+	}
+	conn, ok := groupMap[name]
+	if !ok {
+		return ResourceView[C, T]{}, NewErrResourceNotFound(g.name, name)
+	}
+
+	v := ResourceView[C, T]{Name: name, Config: conn.cfg, Ready: conn.ready}
+	if conn.ready {
+		v.Value = conn.val
+	}
+	return v, nil
+}
@@ -0,0 +1,165 @@
+package registry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// warmupTarget 是 WarmupAll 需要预热的单个资源。
+type warmupTarget[C any, T any] struct {
+	group string
+	name  string
+	conn  *connection[C, T]
+}
+
+// WarmupAll 预先初始化管理器中所有尚未就绪的资源，同时进行的 opener 调用不超过 concurrency 个。
+//
+// 当注册了大量资源时，一次性全部打开可能压垮后端服务，WarmupAll 通过固定大小的工作池限制并发。
+// opener 在锁外执行，不会阻塞其他组的读写；预热期间对同一资源的 Get 会等待预热完成，不会重复调用 opener。
+// 若资源在预热期间被注销，预热创建的实例会通过 closer 关闭并丢弃。
+//
+// ctx 被取消后不再派发新的打开操作，尚未派发的资源记录 ctx 的错误。
+// concurrency 小于 1 时按 1 处理。
+//
+// 返回值:
+//   - map[string]map[string]error: 初始化失败的资源，外层 key 为组名，内层 key 为资源名；
+//     opener 的错误包装为 ErrOpenResourceFailed，未派发的资源记录 ctx 的错误；全部成功时返回空 map
+func (m *manager[C, T]) WarmupAll(ctx context.Context, concurrency int) map[string]map[string]error {
+	concurrency = max(concurrency, 1)
+
+	m.mu.RLock()
+	var targets []warmupTarget[C, T]
+	for groupName, groupMap := range m.groups {
+		for name, conn := range groupMap {
+			if !conn.ready {
+				targets = append(targets, warmupTarget[C, T]{group: groupName, name: name, conn: conn})
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	// 按组名和资源名排序，使派发顺序稳定
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].group != targets[j].group {
+			return targets[i].group < targets[j].group
+		}
+		return targets[i].name < targets[j].name
+	})
+
+	var (
+		mu      sync.Mutex
+		results = make(map[string]map[string]error)
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, concurrency)
+	)
+	record := func(groupName, name string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if results[groupName] == nil {
+			results[groupName] = make(map[string]error)
+		}
+		results[groupName][name] = err
+	}
+
+	for i, target := range targets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for _, rest := range targets[i:] {
+				record(rest.group, rest.name, ctx.Err())
+			}
+			wg.Wait()
+			return results
+		}
+
+		wg.Add(1)
+		go func(target warmupTarget[C, T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := m.warmup(ctx, target); err != nil {
+				record(target.group, target.name, err)
+			}
+		}(target)
+	}
+	wg.Wait()
+	return results
+}
+
+// warmup 在锁外为单个资源调用 opener，成功后在写锁内安装实例。
+//
+// 调用 opener 期间 connection 被标记为预热中，并发的 Get 会等待预热结束而不会重复创建实例。
+func (m *manager[C, T]) warmup(ctx context.Context, target warmupTarget[C, T]) error {
+	m.mu.Lock()
+	conn, ok := m.groups[target.group][target.name]
+	if !ok || conn != target.conn || conn.ready || conn.warming != nil {
+		// 资源已被注销、已由其他路径初始化或正在预热
+		m.mu.Unlock()
+		return nil
+	}
+	done := make(chan struct{})
+	conn.warming = done
+	conn.opens.Add(1)
+	cfg := conn.cfg
+	m.mu.Unlock()
+
+	val, err := m.open(ctx, target.group, target.name, cfg)
+
+	m.mu.Lock()
+	conn.warming = nil
+	close(done)
+	current, ok := m.groups[target.group][target.name]
+	registered := ok && current == conn
+	if err != nil {
+		if registered {
+			conn.lastErr, conn.lastErrAt = err, m.clock()
+			m.queueAwaitChange(target.group, target.name, err)
+		}
+		m.unlockAndNotify()
+		return NewErrOpenResourceFailed(target.group, target.name, err)
+	}
+
+	installed := registered && !conn.ready
+	if installed {
+		conn.val = val
+		conn.ready = true
+		conn.openedAt = m.clock()
+		conn.lastErr, conn.lastErrAt = nil, time.Time{}
+		m.queueReadyChange(target.group, target.name, true)
+	}
+	m.unlockAndNotify()
+
+	// 资源已被注销或在预热期间被 Replace 替换，丢弃本次创建的实例
+	if !installed {
+		_ = m.callCloser(withResource(ctx, target.group, target.name), target.group, target.name, val)
+	}
+	return nil
+}
+
+// awaitWarmup 在资源正由 WarmupAll 创建时等待其完成。
+//
+// 调用方必须持有 manager 的写锁；等待期间会释放写锁，返回时重新持有。
+// 等待结束后若资源已被注销，返回 ErrGroupNotFound 或 ErrResourceNotFound；ctx 结束时返回 ctx 的错误。
+func (g *group[C, T]) awaitWarmup(ctx context.Context, name string, conn *connection[C, T]) error {
+	for conn.warming != nil {
+		done := conn.warming
+		g.m.unlockAndNotify()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			g.m.mu.Lock()
+			return ctx.Err()
+		}
+		g.m.mu.Lock()
+
+		groupMap, ok := g.m.groups[g.name]
+		if !ok {
+			return NewErrGroupNotFound(g.name)
+		}
+		if current, ok := groupMap[name]; !ok || current != conn {
+			return NewErrResourceNotFound(g.name, name)
+		}
+	}
+	return nil
+}